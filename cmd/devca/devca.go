@@ -0,0 +1,39 @@
+package main
+
+import (
+	"flag"
+	"log"
+	"net"
+	"strings"
+
+	"P2P_BitTorrent/security"
+)
+
+// Genera una CA local de desarrollo y los certificados del tracker y de los nodos indicados.
+func main() {
+	out := flag.String("out", "certs", "Carpeta donde se guardan los certificados")
+	trackerHosts := flag.String("tracker", "localhost,127.0.0.1", "Nombres o IPs del tracker, separados por coma")
+	nodes := flag.String("nodes", "localhost:50001,localhost:50002,localhost:50003", "Nodos (ip:puerto) separados por coma; su dirección es su identidad")
+	flag.Parse()
+
+	ca, err := security.NewDevCA(*out)
+	if err != nil {
+		log.Fatalf("Error al generar la CA: %v", err)
+	}
+
+	if err := ca.Issue(*out, "tracker", security.TrackerIdentity, strings.Split(*trackerHosts, ",")); err != nil {
+		log.Fatalf("Error al generar el certificado del tracker: %v", err)
+	}
+
+	for _, nodeID := range strings.Split(*nodes, ",") {
+		host, port, err := net.SplitHostPort(nodeID)
+		if err != nil {
+			log.Fatalf("Nodo inválido %s: %v", nodeID, err)
+		}
+		if err := ca.Issue(*out, "node-"+host+"-"+port, nodeID, []string{host}); err != nil {
+			log.Fatalf("Error al generar el certificado del nodo %s: %v", nodeID, err)
+		}
+	}
+
+	log.Printf("Certificados generados en %s", *out)
+}
@@ -3,47 +3,167 @@ package main
 import (
 	"bufio"
 	"context"
+	"crypto/ed25519"
+	"crypto/sha256"
+	"encoding/hex"
+	"flag"
 	"fmt"
 	"log"
 	"os"
+	"path/filepath"
 	"strings"
+	"time"
 
 	"P2P_BitTorrent/node"
 	pb "P2P_BitTorrent/pb"
+	"P2P_BitTorrent/security"
 
 	"google.golang.org/grpc"
 )
 
 const (
-	trackerAddress = "34.198.140.82:50051" // Dirección y puerto del tracker
+	defaultTrackerAddress = "34.198.140.82:50051" // Dirección y puerto del tracker
 )
 
+// localNode agrupa las operaciones del nodo local que usan los comandos
+type localNode interface {
+	DownloadFile(res *pb.JoinResponse, root, key []byte) ([]byte, error)
+	DownloadCollection(res *pb.JoinResponse, root, key []byte, entries []*pb.CollectionEntry) (map[string][]byte, error)
+	SetBandwidthLimit(direction, peerID string, rate int64) error
+	BandwidthLimits() string
+	Capacity() (capacity, free int64)
+	Labels() map[string]string
+	SendChunkToNode(nodeAddress string, chunk *pb.StoreChunkRequest) error
+	RotateStoreKey() (int, error)
+}
+
 // Función principal del nodo
 func main() {
+	unchokeSlots := flag.Int("unchoke-slots", node.DefaultUnchokeSlots, "Cantidad de peers a los que se sirven chunks simultáneamente")
+	uploadLimit := flag.Int64("up-limit", 0, "Límite global de subida en KB/s (0 = sin límite)")
+	downloadLimit := flag.Int64("down-limit", 0, "Límite global de descarga en KB/s (0 = sin límite)")
+	peerUploadLimit := flag.Int64("peer-up-limit", 0, "Límite de subida hacia cada peer en KB/s (0 = sin límite)")
+	peerDownloadLimit := flag.Int64("peer-down-limit", 0, "Límite de descarga desde cada peer en KB/s (0 = sin límite)")
+	quotaMb := flag.Int64("quota-mb", 0, "Espacio máximo en MB para almacenar chunks (0 = sin límite)")
+	host := flag.String("host", "", "Máquina del nodo, para repartir réplicas entre dominios de falla (por defecto la IP)")
+	rack := flag.String("rack", "", "Rack del nodo")
+	zone := flag.String("zone", "", "Zona del nodo")
+	scrubRate := flag.Int64("scrub-rate", 1024, "KB/s que relee el scrubber para verificar los chunks almacenados (0 = sin límite)")
+	trackerAddress := flag.String("tracker", defaultTrackerAddress, "Dirección ip:puerto del tracker")
+	certFile := flag.String("cert", "", "Certificado TLS del nodo; su CN debe ser la ip:puerto del nodo")
+	keyFile := flag.String("key", "", "Clave privada del certificado TLS del nodo")
+	caFile := flag.String("ca", "", "CA que firma los certificados de la red (activa TLS mutuo)")
+	identityFile := flag.String("identity", "node.key", "Archivo con la clave Ed25519 del nodo (se genera si no existe)")
+	keyringFile := flag.String("keyring", "keyring", "Archivo donde se guardan las claves de los archivos cifrados")
+	trackerKey := flag.String("tracker-key", "", "Clave pública del tracker en hexadecimal (por defecto, la que envíe por TLS)")
+	dataDir := flag.String("data-dir", "", "Carpeta donde se guardan los chunks para conservarlos entre reinicios (vacío = en memoria)")
+	storeKeyFile := flag.String("store-key", "", "Archivo con las claves para cifrar los chunks almacenados (se genera si no existe; vacío = sin cifrar)")
+	downloadDir := flag.String("download-dir", "downloads", "Carpeta donde se guardan los archivos descargados")
+	namespace := flag.String("namespace", "", "Namespace por defecto de los archivos (vacío = el namespace compartido)")
+	flag.Parse()
+
+	labels := make(map[string]string)
+	for key, value := range map[string]string{"host": *host, "rack": *rack, "zone": *zone} {
+		if value != "" {
+			labels[key] = value
+		}
+	}
+
+	creds, err := security.Load(security.Config{CertFile: *certFile, KeyFile: *keyFile, CAFile: *caFile})
+	if err != nil {
+		log.Fatalf("Configuración de TLS inválida: %v", err)
+	}
+
+	identity, err := security.LoadOrCreateIdentity(*identityFile)
+	if err != nil {
+		log.Fatalf("No se pudo cargar la identidad del nodo: %v", err)
+	}
+	fmt.Printf("Clave pública del nodo: %x\n", identity.PublicKey())
+	fmt.Printf("Clave de cifrado del nodo: %x\n", identity.ExchangePublicKey())
+
+	keyring, err := node.LoadKeyring(*keyringFile)
+	if err != nil {
+		log.Fatalf("No se pudo cargar el llavero del nodo: %v", err)
+	}
+
+	var trackerPublicKey []byte
+	if *trackerKey != "" {
+		if trackerPublicKey, err = hex.DecodeString(*trackerKey); err != nil || len(trackerPublicKey) != ed25519.PublicKeySize {
+			log.Fatalf("Clave del tracker inválida: %s", *trackerKey)
+		}
+	} else if creds == nil {
+		log.Printf("Sin -tracker-key ni TLS no se puede verificar la clave del tracker: el nodo rechazará los permisos de acceso")
+	}
+
+	var storeKeys *node.StoreKeys
+	if *storeKeyFile != "" {
+		if *dataDir != "" && insideDir(*storeKeyFile, *dataDir) {
+			log.Fatalf("El archivo de claves del almacén no puede estar dentro de -data-dir: quien se lleve el disco de los chunks se llevaría también sus claves")
+		}
+		if storeKeys, err = node.LoadStoreKeys(*storeKeyFile); err != nil {
+			log.Fatalf("No se pudieron cargar las claves del almacén: %v", err)
+		}
+	}
+
 	// Pedir al usuario que ingrese la ip:puerto del nodo
 	fmt.Print("Ingrese la ip:puerto del nodo (ejemplo: localhost:50001, localhost:50002, ...): ")
 	var nodePort string
 	fmt.Scanln(&nodePort)
 
 	// Inicia el servidor gRPC del nodo para manejar solicitudes de otros nodos
-	go node.StartNodeServer(nodePort)
+	srv := node.NewNodeServer(nodePort, node.Config{
+		UnchokeSlots:      *unchokeSlots,
+		UploadLimit:       *uploadLimit * 1024,
+		DownloadLimit:     *downloadLimit * 1024,
+		PeerUploadLimit:   *peerUploadLimit * 1024,
+		PeerDownloadLimit: *peerDownloadLimit * 1024,
+		QuotaBytes:        *quotaMb << 20,
+		Labels:            labels,
+		ScrubRate:         *scrubRate * 1024,
+		DataDir:           *dataDir,
+		StoreKeys:         storeKeys,
+		TrackerKey:        trackerPublicKey,
+		Credentials:       creds,
+	})
+	if loaded, err := srv.LoadStoredChunks(); err != nil {
+		log.Fatalf("No se pudieron leer los chunks de %s: %v", *dataDir, err)
+	} else if loaded > 0 {
+		log.Printf("%d chunks recuperados de %s", loaded, *dataDir)
+	}
+	go node.StartNodeServer(srv)
 
-	// Conectar al tracker
-	conn, err := grpc.Dial(trackerAddress, grpc.WithInsecure())
+	// Conectar al tracker firmando las solicitudes con la clave del nodo
+	conn, err := grpc.Dial(*trackerAddress, creds.DialOption(), grpc.WithUnaryInterceptor(identity.UnaryClientInterceptor()))
 	if err != nil {
 		log.Fatalf("No se pudo conectar con el tracker: %v", err)
 	}
 	defer conn.Close()
 
 	client := pb.NewTrackerServiceClient(conn)
+	go srv.StartHeartbeat(client, node.HeartbeatInterval)
+	go srv.StartInventoryReport(client, node.InventoryInterval)
+	go srv.StartScrubber(client, node.ScrubPause)
 
 	// Scanner para entrada de comandos del usuario
 	scanner := bufio.NewScanner(os.Stdin)
 
 	fmt.Println("Bienvenido al nodo cliente. Ingrese un comando:")
-	fmt.Println("1. put [filename] [size_mb] - Para subir un archivo")
-	fmt.Println("2. get [filename] - Para descargar un archivo")
+	fmt.Println("1. put [--replicas n] [--durability reduced|standard|high] [--ec [--stripe k+m]] [--encrypt [--recipient clave,...]] [--private] [filename] [size_mb] - Para subir un archivo")
+	fmt.Println("   put [--name colección] [opciones] [carpeta] - Para subir una carpeta como colección")
+	fmt.Println("2. get [--publisher clave] [--root hash] [--key clave] [filename][@vN] - Para descargar un archivo (por defecto la última versión)")
+	fmt.Println("   get [--only ruta,carpeta,patrón] [colección] - Para descargar una colección o solo algunos de sus archivos")
 	fmt.Println("3. leave - Para salir de la red")
+	fmt.Println("4. limit [up|down] [kb/s] [peer|*] - Para cambiar los límites de ancho de banda")
+	fmt.Println("5. domains - Para ver los chunks cuyas réplicas comparten dominio de falla")
+	fmt.Println("6. versions [filename] - Para ver las versiones guardadas de un archivo")
+	fmt.Println("7. rm [filename][@vN] - Para eliminar un archivo (por defecto todas sus versiones)")
+	fmt.Println("8. rotate-key - Para cambiar la clave con la que se cifran los chunks almacenados")
+	fmt.Println("9. acl [filename] [public|private] [+r|-r|+w|-w clave]... - Para ver o cambiar los permisos de un archivo")
+	fmt.Println("10. ns [nombre] [--quota mb] [public|private] [+r|-r|+w|-w clave]... - Para ver, crear o cambiar namespaces")
+	fmt.Println("11. usage - Para ver el espacio y el ancho de banda usados y sus cuotas")
+	fmt.Println("12. ls [prefijo] [--sort name|size|date] [--desc] [--limit n] [--page token] - Para listar los archivos")
+	fmt.Println("13. search [texto|patrón] [--glob] [--ignore-case] [--sort ...] - Para buscar archivos por nombre")
+	fmt.Println("Los comandos put, get, versions, rm y acl aceptan --ns [nombre] para usar otro namespace")
 
 	for scanner.Scan() {
 		input := scanner.Text()
@@ -55,21 +175,98 @@ func main() {
 
 		switch commands[0] {
 		case "put":
-			if len(commands) != 3 {
-				fmt.Println("Uso incorrecto. Ejemplo: put example.txt 10")
-				continue
+			args, options := parseCommand(commands[1:], *namespace, "ec", "encrypt", "private")
+			switch len(args) {
+			case 2:
+				handlePut(client, srv, identity, keyring, args[0], args[1], "", nodePort, options)
+			case 1:
+				// Una carpeta se sube como colección, por defecto con el nombre de la carpeta
+				if info, err := os.Stat(args[0]); err != nil || !info.IsDir() {
+					fmt.Printf("%s no es una carpeta. Para subir un archivo indica su tamaño: put %s 10\n", args[0], args[0])
+					continue
+				}
+				fileName := options["name"]
+				if fileName == "" {
+					fileName = filepath.Base(filepath.Clean(args[0]))
+				}
+				handlePut(client, srv, identity, keyring, fileName, "", args[0], nodePort, options)
+			default:
+				fmt.Println("Uso incorrecto. Ejemplo: put example.txt 10, put --replicas 5 example.txt 10 o put fotos/")
 			}
-			fileName := commands[1]
-			fileSizeMb := commands[2]
-			handlePut(client, fileName, fileSizeMb, nodePort)
 
 		case "get":
-			if len(commands) != 2 {
-				fmt.Println("Uso incorrecto. Ejemplo: get example.txt")
+			args, options := parseCommand(commands[1:], *namespace)
+			if len(args) != 1 {
+				fmt.Println("Uso incorrecto. Ejemplo: get example.txt o get --publisher <clave> example.txt@v2")
+				continue
+			}
+			fileName, version, err := node.ParseVersion(args[0])
+			if err != nil {
+				fmt.Println(err)
+				continue
+			}
+			habdleGet(client, srv, identity, keyring, fileName, version, nodePort, *downloadDir, options)
+
+		case "limit":
+			handleLimit(srv, commands[1:])
+
+		case "domains":
+			handleDomains(client, nodePort)
+
+		case "versions":
+			args, options := parseCommand(commands[1:], *namespace)
+			if len(args) != 1 {
+				fmt.Println("Uso incorrecto. Ejemplo: versions example.txt o versions --ns equipo example.txt")
 				continue
 			}
-			fileName := commands[1]
-			habdleGet(client, fileName, nodePort)
+			handleVersions(client, args[0], nodePort, options["ns"])
+
+		case "rm":
+			args, options := parseCommand(commands[1:], *namespace)
+			if len(args) != 1 {
+				fmt.Println("Uso incorrecto. Ejemplo: rm example.txt o rm example.txt@v2")
+				continue
+			}
+			fileName, version, err := node.ParseVersion(args[0])
+			if err != nil {
+				fmt.Println(err)
+				continue
+			}
+			handleRemove(client, fileName, version, nodePort, options["ns"])
+
+		case "acl":
+			args, options := parseCommand(commands[1:], *namespace)
+			handleACL(client, nodePort, args, options["ns"])
+
+		case "ns":
+			args, options := node.ParseOptions(commands[1:])
+			handleNamespace(client, nodePort, args, options)
+
+		case "rotate-key":
+			handleRotateKey(srv)
+
+		case "usage":
+			handleUsage(client, nodePort)
+
+		case "ls":
+			args, options := parseCommand(commands[1:], *namespace, "desc")
+			if len(args) > 1 {
+				fmt.Println("Uso incorrecto. Ejemplo: ls o ls --sort size --desc informes/")
+				continue
+			}
+			prefix := ""
+			if len(args) == 1 {
+				prefix = args[0]
+			}
+			handleList(client, nodePort, prefix, options)
+
+		case "search":
+			args, options := parseCommand(commands[1:], *namespace, "desc", "glob", "ignore-case")
+			if len(args) != 1 {
+				fmt.Println("Uso incorrecto. Ejemplo: search informe o search --glob '*.pdf'")
+				continue
+			}
+			handleSearch(client, nodePort, args[0], options)
 
 		case "leave":
 			handleLeave(client, nodePort)
@@ -82,81 +279,160 @@ func main() {
 	}
 }
 
-// requestChunkFromNode simula la solicitud de un chunk desde otro nodo
-func requestChunkFromNode(nodeAddress, chunkName string) {
-	// Crear una conexión con el nodo destino
-	conn, err := grpc.Dial(nodeAddress, grpc.WithInsecure())
-	if err != nil {
-		log.Printf("Error al conectar con el nodo %s: %v", nodeAddress, err)
+// handlePut envía una solicitud para subir un archivo al tracker. Si dir no está vacío, sube
+// el contenido real de esa carpeta como una colección; si no, chunks simulados de fileSizeMb.
+func handlePut(client pb.TrackerServiceClient, srv localNode, identity *security.Identity, keyring *node.Keyring, fileName string, fileSizeMb string, dir string, nodeID string, options map[string]string) {
+	var size int
+	var content []byte
+	var entries []*pb.CollectionEntry
+	var err error
+	if dir != "" {
+		if content, entries, err = node.ReadCollection(dir); err != nil {
+			fmt.Println(err)
+			return
+		}
+		size = node.ContentSizeMb(len(content))
+		fmt.Printf("Colección %s: %d archivos, %d bytes\n", fileName, len(entries), len(content))
+	} else if size, err = node.ParseSize(fileSizeMb); err != nil {
+		log.Printf("Error al parsear el tamaño del archivo: %v", err)
 		return
 	}
-	defer conn.Close()
 
-	// Crear un cliente gRPC para el nodo
-	client := pb.NewNodeServiceClient(conn)
+	replicas := 0
+	if value, ok := options["replicas"]; ok {
+		if replicas, err = node.ParseSize(value); err != nil || replicas <= 0 {
+			fmt.Println("El número de réplicas debe ser un entero positivo")
+			return
+		}
+	}
 
-	// Crear la solicitud del chunk
-	req := &pb.ChunkRequest{
-		ChunkId: chunkName, // Solicita el chunk específico
+	// Con --ec el archivo se guarda con erasure coding en lugar de réplicas
+	storageMode := node.StorageReplication
+	var dataShards, parityShards int
+	if hasOption(options, "ec") {
+		storageMode = node.StorageErasure
+	}
+	if value, ok := options["stripe"]; ok {
+		if _, err := fmt.Sscanf(value, "%d+%d", &dataShards, &parityShards); err != nil || dataShards <= 0 || parityShards <= 0 {
+			fmt.Println("Tamaño de franja inválido. Ejemplo: --stripe 4+2")
+			return
+		}
 	}
 
-	// Enviar la solicitud y recibir la respuesta
-	res, err := client.RequestChunk(context.Background(), req)
-	if err != nil {
-		log.Printf("Error al solicitar chunk %s de %s: %v", chunkName, nodeAddress, err)
-		return
+	// Con --encrypt los chunks se cifran antes de salir del nodo; la clave se envuelve para
+	// cada destinatario de --recipient y para el propio nodo
+	encrypt := hasOption(options, "encrypt")
+	recipients := [][]byte{identity.ExchangePublicKey()}
+	if value, ok := options["recipient"]; ok {
+		if !encrypt {
+			fmt.Println("--recipient solo se usa junto con --encrypt")
+			return
+		}
+		for _, field := range strings.Split(value, ",") {
+			recipient, err := hex.DecodeString(field)
+			if err != nil {
+				fmt.Printf("Clave de destinatario inválida: %s\n", field)
+				return
+			}
+			recipients = append(recipients, recipient)
+		}
 	}
 
-	log.Printf("Chunk %s recibido desde %s: %s", chunkName, nodeAddress, res.Message)
-}
+	// Crear la solicitud para el tracker
+	capacity, free := srv.Capacity()
+	req := &pb.JoinRequest{
+		NodeId:        nodeID,
+		Action:        "put",
+		FileName:      fileName,
+		Namespace:     options["ns"],
+		FileSizeMb:    int32(size),
+		CapacityBytes: capacity,
+		FreeBytes:     free,
+		Labels:        srv.Labels(),
+		Replicas:      int32(replicas),
+		Durability:    options["durability"],
+		StorageMode:   storageMode,
+		DataShards:    int32(dataShards),
+		ParityShards:  int32(parityShards),
+		Private:       hasOption(options, "private"),
+	}
 
-// Función para enviar un chunk a un nodo específico
-func SendChunkToNode(nodeAddress string, chunk pb.StoreChunkRequest) {
-	conn, err := grpc.Dial(nodeAddress, grpc.WithInsecure())
+	// Enviar la solicitud al tracker
+	res, err := client.JoinNetwork(context.Background(), req)
 	if err != nil {
-		log.Printf("Error al conectar con el nodo %s: %v", nodeAddress, err)
+		log.Printf("Error al subir archivo: %v", err)
 		return
 	}
-	defer conn.Close()
 
-	client := pb.NewNodeServiceClient(conn)
-	res, err := client.StoreChunk(context.Background(), &chunk)
-	if err != nil {
-		log.Printf("Error al enviar chunk %s a %s: %v", chunk.ChunkId, nodeAddress, err)
-		return
+	fmt.Println(res.Message)
+
+	// Simulación de chunks para enviar
+	chunkSize := 1 // Suponiendo 1 MB por chunk
+	chunks := node.CreateChunks(res.FileId, size, chunkSize)
+	if entries != nil {
+		chunks = node.ContentChunks(res.FileId, content)
 	}
 
-	log.Printf("Respuesta al enviar chunk %s a %s: %s", chunk.ChunkId, nodeAddress, res.Message)
-}
+	var wrappedKeys []*pb.WrappedKey
+	if encrypt {
+		key, err := node.NewFileKey()
+		if err == nil {
+			err = node.EncryptChunks(key, chunks)
+		}
+		for _, recipient := range recipients {
+			if err != nil {
+				break
+			}
+			var wrapped *pb.WrappedKey
+			if wrapped, err = security.WrapKey(key, recipient); err == nil {
+				wrappedKeys = append(wrappedKeys, wrapped)
+			}
+		}
+		if err == nil {
+			err = keyring.Add(res.FileId, key)
+		}
+		if err != nil {
+			log.Printf("Error al cifrar el archivo: %v", err)
+			return
+		}
+		fmt.Printf("Clave del archivo: %x\n", key)
+	}
 
-// handlePut envía una solicitud para subir un archivo al tracker
-func handlePut(client pb.TrackerServiceClient, fileName string, fileSizeMb string, nodeID string) {
-	size, err := node.ParseSize(fileSizeMb)
+	// Cada chunk de datos viaja con su prueba de Merkle para que se pueda verificar por separado
+	root, err := node.AttachMerkleProofs(res.FileId, chunks)
 	if err != nil {
-		log.Printf("Error al parsear el tamaño del archivo: %v", err)
+		log.Printf("Error al calcular el árbol de Merkle del archivo: %v", err)
 		return
 	}
+	fmt.Printf("Raíz Merkle: %x\n", root)
 
-	// Crear la solicitud para el tracker
-	req := &pb.JoinRequest{
-		NodeId:     nodeID,
-		Action:     "put",
-		FileName:   fileName,
-		FileSizeMb: int32(size),
+	// En modo erasure coding también se envían los shards de paridad de cada franja
+	if res.StorageMode == node.StorageErasure {
+		parityChunks, err := node.BuildParityChunks(res, chunks)
+		if err != nil {
+			log.Printf("Error al calcular la paridad del archivo: %v", err)
+			return
+		}
+		chunks = append(chunks, parityChunks...)
 	}
 
-	// Enviar la solicitud al tracker
-	res, err := client.JoinNetwork(context.Background(), req)
+	// Publicar el manifiesto firmado para que quienes descarguen puedan verificar el archivo,
+	// y el tracker, los desafíos de almacenamiento
+	manifest, err := node.BuildManifest(identity, res, fileName, chunks, encrypt, wrappedKeys, entries)
 	if err != nil {
-		log.Printf("Error al subir archivo: %v", err)
+		log.Printf("Error al crear el manifiesto del archivo: %v", err)
+		return
+	}
+	if _, err := client.PublishManifest(context.Background(), &pb.PublishManifestRequest{NodeId: nodeID, Manifest: manifest}); err != nil {
+		log.Printf("Error al publicar el manifiesto del archivo: %v", err)
 		return
 	}
 
-	fmt.Println(res.Message)
-
-	// Simulación de chunks para enviar
-	chunkSize := 1 // Suponiendo 1 MB por chunk
-	chunks := node.CreateChunks(fileName, size, chunkSize)
+	// Cada chunk lleva el permiso del tracker para que los nodos acepten guardarlo
+	for _, chunk := range chunks {
+		chunk.NodeId = nodeID
+		chunk.Token = res.AccessToken
+	}
 
 	// Enviar cada chunk a los nodos correspondientes en el ChunkMap
 	for chunkID, chunkInfo := range res.ChunkMap {
@@ -166,18 +442,51 @@ func handlePut(client pb.TrackerServiceClient, fileName string, fileSizeMb strin
 			// Iterar sobre todos los nodos que almacenan este chunk
 			for _, targetNode := range chunkInfo.Nodes {
 				// Enviar el chunk al nodo correspondiente
-				go SendChunkToNode(targetNode, *chunk)
+				go srv.SendChunkToNode(targetNode, chunk)
 			}
 		}
 	}
 }
 
-// habdleGet envía una solicitud para descargar un archivo al tracker y se conecta a los nodos correctos
-func habdleGet(client pb.TrackerServiceClient, fileName string, nodeID string) {
+// habdleGet envía una solicitud para descargar un archivo al tracker, descarga sus chunks y lo guarda localmente
+// Si se indica publisher, el archivo debe tener un manifiesto firmado con esa clave, y si se
+// indica root, cada chunk se verifica contra esa raíz de Merkle. Los archivos cifrados se
+// descifran con la clave de --key o, si no se indica, con la del llavero o la envuelta para
+// el nodo en el manifiesto.
+func habdleGet(client pb.TrackerServiceClient, srv localNode, identity *security.Identity, keyring *node.Keyring, fileName string, version int32, nodeID string, downloadDir string, options map[string]string) {
+	publisher := options["publisher"]
+	var root, key []byte
+	var err error
+	if value, ok := options["root"]; ok {
+		if root, err = hex.DecodeString(value); err != nil || len(root) != sha256.Size {
+			fmt.Println("Raíz de Merkle inválida: debe ser un hash SHA-256 en hexadecimal")
+			return
+		}
+	}
+	if value, ok := options["key"]; ok {
+		if key, err = hex.DecodeString(value); err != nil || len(key) != node.FileKeySize {
+			fmt.Println("Clave de archivo inválida: debe tener 32 bytes en hexadecimal")
+			return
+		}
+	}
+
+	// Las selecciones de --only se envían al tracker, que cobra y autoriza solo sus chunks
+	var selections []string
+	if value, ok := options["only"]; ok {
+		selections = strings.Split(value, ",")
+	}
+
+	capacity, free := srv.Capacity()
 	req := &pb.JoinRequest{
-		NodeId:   nodeID,
-		Action:   "get",
-		FileName: fileName,
+		NodeId:        nodeID,
+		Action:        "get",
+		FileName:      fileName,
+		Namespace:     options["ns"],
+		CapacityBytes: capacity,
+		FreeBytes:     free,
+		Labels:        srv.Labels(),
+		Version:       version,
+		Only:          selections,
 	}
 
 	res, err := client.JoinNetwork(context.Background(), req)
@@ -187,16 +496,428 @@ func habdleGet(client pb.TrackerServiceClient, fileName string, nodeID string) {
 	}
 
 	fmt.Println(res.Message)
+	if len(res.ChunkMap) == 0 {
+		return
+	}
 
-	// Solicitar cada chunk a su nodo correspondiente
-	for chunkID, chunkInfo := range res.ChunkMap {
-		// Seleccionamos un nodo para cada chunk (podríamos elegir el primer nodo o usar alguna estrategia)
-		if len(chunkInfo.Nodes) > 0 {
-			targetNode := chunkInfo.Nodes[0]             // Aquí seleccionamos el primer nodo como ejemplo
-			go requestChunkFromNode(targetNode, chunkID) // Llamar a cada nodo concurrentemente con su chunk correspondiente
-		} else {
-			log.Printf("No hay nodos disponibles para el chunk %s", chunkID)
+	// Verificar quién publicó el archivo antes de descargarlo
+	switch {
+	case res.Manifest == nil && publisher != "":
+		fmt.Println("El archivo no tiene manifiesto firmado; se cancela la descarga.")
+		return
+	case res.Manifest == nil:
+		fmt.Println("Advertencia: el archivo no tiene manifiesto firmado, no se puede verificar su contenido.")
+	case publisher != "" && node.PublisherFingerprint(res.Manifest) != publisher:
+		fmt.Printf("El archivo fue publicado por %s, no por %s; se cancela la descarga.\n", node.PublisherFingerprint(res.Manifest), publisher)
+		return
+	default:
+		fmt.Printf("Archivo publicado por %s\n", node.PublisherFingerprint(res.Manifest))
+	}
+
+	// Solicitar cada chunk a los nodos que lo almacenan y reconstruir el archivo
+	if res.Manifest != nil && res.Manifest.Encrypted && key == nil {
+		if key, err = keyring.FileKey(identity, res.Manifest); err != nil {
+			fmt.Printf("El archivo está cifrado y no se tiene su clave (%v); use --key.\n", err)
+			return
+		}
+	}
+
+	// Una colección se guarda como una carpeta con sus archivos, o solo con los elegidos
+	if len(res.Manifest.GetEntries()) > 0 {
+		entries, err := security.SelectEntries(res.Manifest, selections)
+		if err != nil {
+			fmt.Println(err)
+			return
+		}
+		files, err := srv.DownloadCollection(res, root, key, entries)
+		if err != nil {
+			log.Printf("Error al descargar la colección: %v", err)
+			return
+		}
+		dir := filepath.Join(downloadDir, fileName)
+		written, err := node.WriteCollection(dir, entries, files)
+		if err != nil {
+			log.Printf("Error al guardar la colección: %v", err)
+			return
+		}
+		fmt.Printf("Colección %s descargada en %s (%d de %d archivos, %d bytes)\n", fileName, dir, len(entries), len(res.Manifest.Entries), written)
+		return
+	}
+	if _, ok := options["only"]; ok {
+		fmt.Println("--only solo se usa con colecciones; el archivo se descarga completo.")
+	}
+
+	content, err := srv.DownloadFile(res, root, key)
+	if err != nil {
+		log.Printf("Error al descargar archivo: %v", err)
+		return
+	}
+
+	path := filepath.Join(downloadDir, fileName)
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		log.Printf("Error al crear la carpeta de descargas: %v", err)
+		return
+	}
+	if err := os.WriteFile(path, content, 0644); err != nil {
+		log.Printf("Error al guardar el archivo: %v", err)
+		return
+	}
+	fmt.Printf("Archivo %s descargado en %s (%d bytes)\n", fileName, path, len(content))
+}
+
+// insideDir indica si path está dentro de la carpeta dir.
+func insideDir(path, dir string) bool {
+	absPath, err := filepath.Abs(path)
+	if err != nil {
+		return false
+	}
+	absDir, err := filepath.Abs(dir)
+	if err != nil {
+		return false
+	}
+	rel, err := filepath.Rel(absDir, absPath)
+	return err == nil && rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator))
+}
+
+// hasOption indica si el comando incluyó la opción indicada
+func hasOption(options map[string]string, name string) bool {
+	_, ok := options[name]
+	return ok
+}
+
+// parseCommand separa los argumentos y las opciones de un comando; si no se indica --ns,
+// usa el namespace por defecto del nodo.
+func parseCommand(args []string, namespace string, flags ...string) ([]string, map[string]string) {
+	args, options := node.ParseOptions(args, flags...)
+	if _, ok := options["ns"]; !ok {
+		options["ns"] = namespace
+	}
+	return args, options
+}
+
+// handleRotateKey cambia la clave del almacén de chunks y los vuelve a cifrar con ella
+func handleRotateKey(srv localNode) {
+	resealed, err := srv.RotateStoreKey()
+	if err != nil {
+		fmt.Printf("No se pudo rotar la clave del almacén: %v\n", err)
+		return
+	}
+	fmt.Printf("Clave del almacén rotada: %d chunks re-cifrados\n", resealed)
+}
+
+// handleLimit muestra o cambia los límites de ancho de banda del nodo
+func handleLimit(srv localNode, args []string) {
+	if len(args) == 0 {
+		fmt.Println(srv.BandwidthLimits())
+		return
+	}
+	if len(args) < 2 || len(args) > 3 {
+		fmt.Println("Uso incorrecto. Ejemplo: limit up 512 o limit down 128 localhost:50002")
+		return
+	}
+
+	rate, err := node.ParseSize(args[1])
+	if err != nil || rate < 0 {
+		fmt.Println("Límite inválido, debe ser un número de KB/s (0 = sin límite)")
+		return
+	}
+
+	peerID := ""
+	if len(args) == 3 {
+		peerID = args[2]
+	}
+
+	if err := srv.SetBandwidthLimit(args[0], peerID, int64(rate)*1024); err != nil {
+		fmt.Println(err)
+		return
+	}
+	fmt.Println(srv.BandwidthLimits())
+}
+
+// handleDomains muestra los chunks cuyas réplicas comparten un dominio de falla
+func handleDomains(client pb.TrackerServiceClient, nodeID string) {
+	res, err := client.GetDomainReport(context.Background(), &pb.DomainReportRequest{NodeId: nodeID})
+	if err != nil {
+		log.Printf("Error al obtener el reporte de dominios: %v", err)
+		return
+	}
+
+	if len(res.Chunks) == 0 {
+		fmt.Println("Todas las réplicas están en dominios de falla distintos.")
+		return
+	}
+	for _, chunk := range res.Chunks {
+		fmt.Printf("%s: %d réplicas en el %s %s (%s)\n", chunk.ChunkId, len(chunk.Nodes), chunk.Level, chunk.Domain, strings.Join(chunk.Nodes, ", "))
+	}
+}
+
+// handleVersions muestra las versiones guardadas de un archivo
+func handleVersions(client pb.TrackerServiceClient, fileName string, nodeID string, namespace string) {
+	res, err := client.ListVersions(context.Background(), &pb.FileRequest{FileName: fileName, Namespace: namespace, NodeId: nodeID})
+	if err != nil {
+		log.Printf("Error al obtener las versiones: %v", err)
+		return
+	}
+
+	for _, version := range res.Versions {
+		createdAt := time.Unix(version.CreatedAt, 0).Format("2006-01-02 15:04:05")
+		fmt.Printf("v%d  %s  %d MB  %s  %s\n", version.Version, version.FileId, version.FileSizeMb, version.StorageMode, createdAt)
+	}
+}
+
+// handleRemove envía una solicitud para eliminar un archivo al tracker
+func handleRemove(client pb.TrackerServiceClient, fileName string, version int32, nodeID string, namespace string) {
+	res, err := client.DeleteFile(context.Background(), &pb.DeleteFileRequest{FileName: fileName, Version: version, NodeId: nodeID, Namespace: namespace})
+	if err != nil {
+		log.Printf("Error al eliminar archivo: %v", err)
+		return
+	}
+
+	fmt.Println(res.Message)
+}
+
+// handleACL consulta los permisos de un archivo o, si se indican cambios, se los envía al
+// tracker. Los cambios son "public", "private" y "+r", "-r", "+w" o "-w" seguidos de la
+// clave pública de un nodo.
+func handleACL(client pb.TrackerServiceClient, nodeID string, args []string, namespace string) {
+	if len(args) == 0 {
+		fmt.Println("Uso incorrecto. Ejemplo: acl example.txt o acl example.txt private +r <clave>")
+		return
+	}
+
+	req, err := parseAclChanges(args[1:])
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+	req.NodeId = nodeID
+	req.FileName = args[0]
+	req.Namespace = namespace
+
+	res, err := client.UpdateFileAcl(context.Background(), req)
+	if err != nil {
+		log.Printf("Error con los permisos del archivo: %v", err)
+		return
+	}
+
+	fmt.Println(res.Message)
+	printAcl(res.Owner, res.Public, res.Readers, res.Writers)
+}
+
+// parseAclChanges interpreta los cambios de permisos "public", "private" y "+r", "-r", "+w"
+// o "-w" seguidos de la clave pública de un nodo.
+func parseAclChanges(args []string) (*pb.AclRequest, error) {
+	req := &pb.AclRequest{}
+	for i := 0; i < len(args); i++ {
+		switch args[i] {
+		case "public", "private":
+			req.Visibility = args[i]
+			continue
+		case "+r", "-r", "+w", "-w":
+		default:
+			return nil, fmt.Errorf("cambio de permisos desconocido: %s", args[i])
 		}
+		if i+1 >= len(args) {
+			return nil, fmt.Errorf("falta la clave después de %s", args[i])
+		}
+		key, err := hex.DecodeString(args[i+1])
+		if err != nil {
+			return nil, fmt.Errorf("clave inválida: %s", args[i+1])
+		}
+		switch args[i] {
+		case "+r":
+			req.GrantRead = append(req.GrantRead, key)
+		case "-r":
+			req.RevokeRead = append(req.RevokeRead, key)
+		case "+w":
+			req.GrantWrite = append(req.GrantWrite, key)
+		case "-w":
+			req.RevokeWrite = append(req.RevokeWrite, key)
+		}
+		i++
+	}
+	return req, nil
+}
+
+// printAcl muestra el dueño, la visibilidad y las claves autorizadas de un archivo o namespace.
+func printAcl(owner []byte, public bool, readers, writers [][]byte) {
+	visibility := "private"
+	if public {
+		visibility = "public"
+	}
+	fmt.Printf("  dueño: %x (%s)\n", owner, visibility)
+	for _, key := range readers {
+		fmt.Printf("  lectura: %x\n", key)
+	}
+	for _, key := range writers {
+		fmt.Printf("  escritura: %x\n", key)
+	}
+}
+
+// handleNamespace lista los namespaces o, si se indica un nombre, crea el namespace o cambia
+// su cuota (--quota en MB, -1 para quitarla) y los permisos de sus archivos nuevos.
+func handleNamespace(client pb.TrackerServiceClient, nodeID string, args []string, options map[string]string) {
+	if len(args) == 0 {
+		res, err := client.ListNamespaces(context.Background(), &pb.ListNamespacesRequest{})
+		if err != nil {
+			log.Printf("Error al listar los namespaces: %v", err)
+			return
+		}
+		for _, ns := range res.Namespaces {
+			printNamespace(ns)
+		}
+		return
+	}
+
+	changes, err := parseAclChanges(args[1:])
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+	req := &pb.NamespaceRequest{
+		NodeId:      nodeID,
+		Name:        args[0],
+		Visibility:  changes.Visibility,
+		GrantRead:   changes.GrantRead,
+		RevokeRead:  changes.RevokeRead,
+		GrantWrite:  changes.GrantWrite,
+		RevokeWrite: changes.RevokeWrite,
+	}
+	if value, ok := options["quota"]; ok {
+		if _, err := fmt.Sscanf(value, "%d", &req.QuotaMb); err != nil || req.QuotaMb == 0 || req.QuotaMb < -1 {
+			fmt.Println("Cuota inválida: debe ser una cantidad de MB o -1 para quitarla")
+			return
+		}
+	}
+
+	res, err := client.UpdateNamespace(context.Background(), req)
+	if err != nil {
+		log.Printf("Error con el namespace: %v", err)
+		return
+	}
+
+	fmt.Println(res.Message)
+	printNamespace(res.Namespace)
+}
+
+// printNamespace muestra el uso, la cuota y los permisos por defecto de un namespace.
+func printNamespace(ns *pb.NamespaceInfo) {
+	quota := "sin límite"
+	if ns.QuotaBytes > 0 {
+		quota = fmt.Sprintf("%d MB", ns.QuotaBytes>>20)
+	}
+	fmt.Printf("%s  %d archivos  %d MB usados  cuota: %s\n", ns.Name, ns.Files, ns.UsedBytes>>20, quota)
+	printAcl(ns.Owner, ns.Public, ns.Readers, ns.Writers)
+}
+
+// handleUsage muestra el espacio que ocupan los archivos subidos por el nodo, lo que
+// descargó en el período actual y sus cuotas
+func handleUsage(client pb.TrackerServiceClient, nodeID string) {
+	res, err := client.GetUsage(context.Background(), &pb.UsageRequest{NodeId: nodeID})
+	if err != nil {
+		log.Printf("Error al obtener el uso: %v", err)
+		return
+	}
+
+	periodEnd := time.Unix(res.PeriodEndsAt, 0).Format("2006-01-02 15:04:05")
+	fmt.Printf("Almacenado: %s\n", formatQuota(res.StoredBytes, res.StorageQuotaBytes))
+	fmt.Printf("Descargado: %s (el período termina el %s)\n", formatQuota(res.DownloadedBytes, res.DownloadQuotaBytes), periodEnd)
+	for _, ns := range res.Namespaces {
+		name := ns.Namespace
+		if name == "" {
+			name = "(por defecto)"
+		}
+		fmt.Printf("  %s: %d archivos, %d MB subidos; el namespace usa %s\n", name, ns.Files, ns.StoredBytes>>20, formatQuota(ns.NamespaceUsedBytes, ns.NamespaceQuotaBytes))
+	}
+}
+
+// formatQuota muestra los MB usados y la cuota, si la hay.
+func formatQuota(used, quota int64) string {
+	if quota == 0 {
+		return fmt.Sprintf("%d MB (sin límite)", used>>20)
+	}
+	return fmt.Sprintf("%d de %d MB", used>>20, quota>>20)
+}
+
+// handleList muestra los archivos del namespace que empiezan con el prefijo
+func handleList(client pb.TrackerServiceClient, nodeID string, prefix string, options map[string]string) {
+	pageSize, ok := parsePageSize(options)
+	if !ok {
+		return
+	}
+	res, err := client.ListFiles(context.Background(), &pb.ListFilesRequest{
+		NodeId:     nodeID,
+		Namespace:  options["ns"],
+		Prefix:     prefix,
+		SortBy:     options["sort"],
+		Descending: hasOption(options, "desc"),
+		PageSize:   pageSize,
+		PageToken:  options["page"],
+	})
+	if err != nil {
+		log.Printf("Error al listar los archivos: %v", err)
+		return
+	}
+	printFiles(res)
+}
+
+// handleSearch muestra los archivos del namespace cuyo nombre contiene el texto o cumple
+// el patrón glob
+func handleSearch(client pb.TrackerServiceClient, nodeID string, query string, options map[string]string) {
+	pageSize, ok := parsePageSize(options)
+	if !ok {
+		return
+	}
+	res, err := client.SearchFiles(context.Background(), &pb.SearchFilesRequest{
+		NodeId:     nodeID,
+		Namespace:  options["ns"],
+		Query:      strings.Trim(query, "'\""),
+		Glob:       hasOption(options, "glob"),
+		IgnoreCase: hasOption(options, "ignore-case"),
+		SortBy:     options["sort"],
+		Descending: hasOption(options, "desc"),
+		PageSize:   pageSize,
+		PageToken:  options["page"],
+	})
+	if err != nil {
+		log.Printf("Error al buscar archivos: %v", err)
+		return
+	}
+	printFiles(res)
+}
+
+// parsePageSize lee la opción --limit de ls y search.
+func parsePageSize(options map[string]string) (int32, bool) {
+	value, ok := options["limit"]
+	if !ok {
+		return 0, true
+	}
+	limit, err := node.ParseSize(value)
+	if err != nil || limit <= 0 {
+		fmt.Println("Límite inválido: debe ser una cantidad de archivos mayor que 0")
+		return 0, false
+	}
+	return int32(limit), true
+}
+
+// printFiles muestra una página de archivos y cómo pedir la siguiente.
+func printFiles(res *pb.ListFilesResponse) {
+	if len(res.Files) == 0 {
+		fmt.Println("No se encontraron archivos.")
+	}
+	for _, file := range res.Files {
+		visibility := "private"
+		if file.Public {
+			visibility = "public"
+		}
+		if file.CollectionFiles > 0 {
+			visibility += fmt.Sprintf("  colección de %d archivos", file.CollectionFiles)
+		}
+		updatedAt := time.Unix(file.UpdatedAt, 0).Format("2006-01-02 15:04:05")
+		fmt.Printf("%s  %d MB  v%d (%d versiones)  %s  %s\n", file.Name, file.FileSizeMb, file.Version, file.Versions, updatedAt, visibility)
+	}
+	if res.NextPageToken != "" {
+		fmt.Printf("Hay más archivos: repita el comando con --page %s\n", res.NextPageToken)
 	}
 }
 
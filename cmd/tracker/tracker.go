@@ -1,26 +1,76 @@
 package main
 
 import (
+	"flag"
 	"log"
 	"net"
 
 	pb "P2P_BitTorrent/pb"
+	"P2P_BitTorrent/security"
 	"P2P_BitTorrent/tracker" // El paquete tracker contendrá la lógica del servidor
 
 	"google.golang.org/grpc"
 )
 
 func main() {
+	placementName := flag.String("placement", tracker.PlacementLeastLoaded, "Política de ubicación de réplicas: least-loaded, weighted, random o consistent-hash")
+	replicas := flag.Int("replicas", tracker.DefaultReplicas, "Cantidad de réplicas por chunk")
+	maxReplicas := flag.Int("max-replicas", tracker.DefaultMaxReplicas, "Réplicas por chunk que puede pedir un archivo como máximo")
+	keepVersions := flag.Int("keep-versions", 0, "Versiones que se conservan por archivo (0 = todas)")
+	versionMaxAge := flag.Duration("version-max-age", 0, "Edad máxima de las versiones anteriores, por ejemplo 72h (0 = sin límite)")
+	orphanGrace := flag.Duration("orphan-grace", tracker.DefaultOrphanGrace, "Espera antes de borrar chunks huérfanos o quitar chunks que un nodo ya no tiene")
+	certFile := flag.String("cert", "", "Certificado TLS del tracker")
+	keyFile := flag.String("key", "", "Clave privada del certificado TLS del tracker")
+	caFile := flag.String("ca", "", "CA que firma los certificados de la red (activa TLS mutuo)")
+	storageQuotaMb := flag.Int64("storage-quota-mb", 0, "MB que pueden ocupar todas las versiones subidas por cada nodo (0 = sin límite)")
+	downloadQuotaMb := flag.Int64("download-quota-mb", 0, "MB que cada nodo puede descargar por período (0 = sin límite)")
+	quotaPeriod := flag.Duration("quota-period", tracker.DefaultQuotaPeriod, "Período de la cuota de descarga")
+	identityFile := flag.String("identity", "tracker.key", "Archivo con la clave Ed25519 con la que el tracker firma los permisos de acceso (se genera si no existe)")
+	flag.Parse()
+
+	placement, err := tracker.NewPlacementPolicy(*placementName)
+	if err != nil {
+		log.Fatalf("Configuración inválida: %v", err)
+	}
+
+	creds, err := security.Load(security.Config{CertFile: *certFile, KeyFile: *keyFile, CAFile: *caFile})
+	if err != nil {
+		log.Fatalf("Configuración de TLS inválida: %v", err)
+	}
+
+	identity, err := security.LoadOrCreateIdentity(*identityFile)
+	if err != nil {
+		log.Fatalf("No se pudo cargar la clave del tracker: %v", err)
+	}
+	log.Printf("Clave pública del tracker: %x", identity.PublicKey())
+
 	// Configurar el servidor gRPC
 	lis, err := net.Listen("tcp", ":50051")
 	if err != nil {
 		log.Fatalf("Error al iniciar el servidor: %v", err)
 	}
 
-	s := grpc.NewServer()
-	trackerServer := tracker.NewTrackerServer()
+	s := grpc.NewServer(creds.ServerOptions()...)
+	trackerServer := tracker.NewTrackerServer(tracker.Config{
+		Placement:     placement,
+		Replicas:      *replicas,
+		MaxReplicas:   *maxReplicas,
+		KeepVersions:  *keepVersions,
+		VersionMaxAge: *versionMaxAge,
+		OrphanGrace:   *orphanGrace,
+		Credentials:   creds,
+		Identity:      identity,
+		StorageQuota:  *storageQuotaMb << 20,
+		DownloadQuota: *downloadQuotaMb << 20,
+		QuotaPeriod:   *quotaPeriod,
+	})
 	pb.RegisterTrackerServiceServer(s, trackerServer)
 
+	go trackerServer.StartRepairLoop(tracker.RepairInterval)
+	go trackerServer.StartRetentionLoop(tracker.RetentionInterval)
+	go trackerServer.StartGCLoop(tracker.GCInterval)
+	go trackerServer.StartChallengeLoop(tracker.ChallengeInterval)
+
 	log.Println("Tracker corriendo en el puerto 50051...")
 	if err := s.Serve(lis); err != nil {
 		log.Fatalf("Error al correr el servidor: %v", err)
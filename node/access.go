@@ -0,0 +1,117 @@
+package node
+
+import (
+	"bytes"
+	"context"
+	"crypto/ed25519"
+	"errors"
+	"log"
+	"slices"
+	"strconv"
+	"strings"
+
+	"P2P_BitTorrent/pb"
+	"P2P_BitTorrent/security"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+// pinTrackerKey fija la clave con la que el tracker firma los permisos de acceso a partir
+// de una respuesta del tracker, solo si no se configuró y la conexión está autenticada con el
+// certificado del tracker; sin TLS cualquiera podría responder en su lugar. Una clave
+// distinta a la fijada se ignora.
+func (s *nodeServer) pinTrackerKey(key []byte, tracker *peer.Peer) {
+	if len(key) != ed25519.PublicKeySize {
+		return
+	}
+
+	identity, verified := security.CertificateIdentity(tracker)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	switch {
+	case bytes.Equal(s.trackerKey, key):
+	case s.trackerKey != nil:
+		log.Printf("El tracker respondió con la clave %x en lugar de %x; se ignora", key, s.trackerKey)
+	case !verified || identity != security.TrackerIdentity:
+		if !s.trackerKeyWarned {
+			log.Printf("La conexión con el tracker no está autenticada con TLS; su clave no se acepta sin -tracker-key")
+			s.trackerKeyWarned = true
+		}
+	default:
+		s.trackerKey = ed25519.PublicKey(key)
+		log.Printf("Clave del tracker: %x", key)
+	}
+}
+
+// requesterOf devuelve el nodo que hace una solicitud: con TLS mutuo, el del certificado del
+// cliente; sin TLS solo se cuenta con el node_id que declara la propia solicitud.
+func requesterOf(ctx context.Context, nodeID string) string {
+	if identity, verified := security.PeerIdentity(ctx); verified {
+		return identity
+	}
+	return nodeID
+}
+
+// accessInterceptor exige un permiso del tracker antes de servir (RequestChunk) o guardar
+// (StoreChunk) un chunk, y una orden firmada por el tracker antes de copiarlo
+// (ReplicateChunk), reconstruirlo a partir de su franja (RebuildShards), borrarlo
+// (DeleteChunk) o responder un desafío sobre él (Challenge). Las
+// órdenes se entregan al nodo que las cumple. Todo se verifica con la clave del tracker, sin
+// consultarlo, y se rechazan las solicitudes que no se sabe cómo verificar.
+func (s *nodeServer) accessInterceptor(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	var chunkID, requester, scope string
+	var token *pb.AccessToken
+	switch r := req.(type) {
+	case *pb.ChunkRequest:
+		chunkID, requester, token, scope = r.ChunkId, requesterOf(ctx, r.NodeId), r.Token, security.ScopeRead
+	case *pb.StoreChunkRequest:
+		chunkID, requester, token, scope = r.ChunkId, requesterOf(ctx, r.NodeId), r.Token, security.ScopeWrite
+	case *pb.ReplicateChunkRequest:
+		chunkID, requester, token, scope = r.ChunkId, s.nodeID, r.Order, security.ScopeReplicate
+	case *pb.DeleteChunkRequest:
+		chunkID, requester, token, scope = r.ChunkId, s.nodeID, r.Token, security.ScopeDelete
+	case *pb.ChallengeRequest:
+		chunkID, requester, token, scope = r.ChunkId, s.nodeID, r.Token, security.ScopeChallenge
+	case *pb.RebuildShardsRequest:
+		// La orden cubre todo el archivo; el servicio verifica que cada shard sea de él
+		chunkID, requester, token, scope = ChunkID(&pb.ChunkKey{FileId: r.FileId}), s.nodeID, r.Order, security.ScopeReplicate
+	default:
+		log.Printf("Solicitud %s rechazada: no requiere un permiso conocido", info.FullMethod)
+		return nil, status.Errorf(codes.PermissionDenied, "método %s no permitido", info.FullMethod)
+	}
+
+	if err := s.checkAccess(chunkID, requester, token, scope); err != nil {
+		log.Printf("Solicitud %s del chunk %s de %s rechazada: %v", info.FullMethod, chunkID, requester, err)
+		return nil, status.Errorf(codes.PermissionDenied, "acceso al chunk %s denegado: %v", chunkID, err)
+	}
+	return handler(ctx, req)
+}
+
+// checkAccess verifica que una solicitud traiga un permiso del tracker vigente, con el
+// alcance indicado, entregado al nodo requester y para el archivo al que pertenece el chunk;
+// si el permiso se limita a algunos chunks, el pedido debe ser uno de ellos.
+func (s *nodeServer) checkAccess(chunkID, requester string, token *pb.AccessToken, scope string) error {
+	s.mu.Lock()
+	trackerKey := s.trackerKey
+	s.mu.Unlock()
+
+	if err := security.VerifyToken(token, trackerKey, requester, scope); err != nil {
+		return err
+	}
+	i := strings.LastIndex(chunkID, "-")
+	if i <= 0 || chunkID[:i] != token.FileId {
+		return errors.New("el permiso de acceso es de otro archivo")
+	}
+	if len(token.Chunks) > 0 {
+		index, err := strconv.ParseInt(chunkID[i+1:], 10, 32)
+		if err != nil || !slices.Contains(token.Chunks, int32(index)) {
+			return errors.New("el permiso de acceso no incluye este chunk")
+		}
+	}
+	return nil
+}
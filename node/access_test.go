@@ -0,0 +1,172 @@
+package node
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"testing"
+	"time"
+
+	"P2P_BitTorrent/pb"
+	"P2P_BitTorrent/security"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+// tlsPeer simula el otro extremo de una conexión TLS con un certificado verificado con el CN indicado.
+func tlsPeer(commonName string) *peer.Peer {
+	cert := &x509.Certificate{Subject: pkix.Name{CommonName: commonName}}
+	state := tls.ConnectionState{VerifiedChains: [][]*x509.Certificate{{cert}}}
+	return &peer.Peer{AuthInfo: credentials.TLSInfo{State: state}}
+}
+
+// newTracker crea la identidad de un tracker de prueba.
+func newTracker(t *testing.T) *security.Identity {
+	t.Helper()
+	identity, err := security.NewIdentity()
+	if err != nil {
+		t.Fatalf("NewIdentity: %v", err)
+	}
+	return identity
+}
+
+// signToken firma un permiso de acceso con la clave del tracker.
+func signToken(t *testing.T, tracker *security.Identity, fileID, nodeID, scope string, chunks ...int32) *pb.AccessToken {
+	t.Helper()
+	token := &pb.AccessToken{FileId: fileID, NodeId: nodeID, Scope: scope, Chunks: chunks, ExpiresAt: time.Now().Add(time.Minute).Unix()}
+	if err := tracker.SignToken(token); err != nil {
+		t.Fatalf("SignToken: %v", err)
+	}
+	return token
+}
+
+func TestAccessInterceptor(t *testing.T) {
+	tracker := newTracker(t)
+	server := NewNodeServer("10.0.0.1:50000", Config{TrackerKey: tracker.PublicKey()})
+	other := newTracker(t)
+	forged := &pb.AccessToken{FileId: "archivo", NodeId: "10.0.0.2:50000", Scope: security.ScopeRead, ExpiresAt: time.Now().Add(time.Minute).Unix()}
+	if err := other.SignToken(forged); err != nil {
+		t.Fatalf("SignToken: %v", err)
+	}
+
+	tests := []struct {
+		name     string
+		peer     *peer.Peer // nil = sin TLS.
+		req      interface{}
+		wantCode codes.Code
+	}{
+		{
+			// Sin TLS el node_id declarado es lo único que identifica al nodo
+			name:     "lectura con permiso",
+			req:      &pb.ChunkRequest{ChunkId: "archivo-1", NodeId: "10.0.0.2:50000", Token: signToken(t, tracker, "archivo", "10.0.0.2:50000", security.ScopeRead)},
+			wantCode: codes.OK,
+		},
+		{
+			name:     "sin permiso",
+			req:      &pb.ChunkRequest{ChunkId: "archivo-1", NodeId: "10.0.0.2:50000"},
+			wantCode: codes.PermissionDenied,
+		},
+		{
+			name:     "permiso de otro tracker",
+			req:      &pb.ChunkRequest{ChunkId: "archivo-1", NodeId: "10.0.0.2:50000", Token: forged},
+			wantCode: codes.PermissionDenied,
+		},
+		{
+			name:     "permiso de otro archivo",
+			req:      &pb.ChunkRequest{ChunkId: "otro-1", NodeId: "10.0.0.2:50000", Token: signToken(t, tracker, "archivo", "10.0.0.2:50000", security.ScopeRead)},
+			wantCode: codes.PermissionDenied,
+		},
+		{
+			name:     "permiso de escritura para leer",
+			req:      &pb.ChunkRequest{ChunkId: "archivo-1", NodeId: "10.0.0.2:50000", Token: signToken(t, tracker, "archivo", "10.0.0.2:50000", security.ScopeWrite)},
+			wantCode: codes.PermissionDenied,
+		},
+		{
+			name:     "chunk incluido en el permiso",
+			req:      &pb.ChunkRequest{ChunkId: "archivo-3", NodeId: "10.0.0.2:50000", Token: signToken(t, tracker, "archivo", "10.0.0.2:50000", security.ScopeRead, 2, 3)},
+			wantCode: codes.OK,
+		},
+		{
+			name:     "chunk fuera del permiso",
+			req:      &pb.ChunkRequest{ChunkId: "archivo-4", NodeId: "10.0.0.2:50000", Token: signToken(t, tracker, "archivo", "10.0.0.2:50000", security.ScopeRead, 2, 3)},
+			wantCode: codes.PermissionDenied,
+		},
+		{
+			name:     "permiso propio con TLS",
+			peer:     tlsPeer("10.0.0.2:50000"),
+			req:      &pb.ChunkRequest{ChunkId: "archivo-1", NodeId: "10.0.0.2:50000", Token: signToken(t, tracker, "archivo", "10.0.0.2:50000", security.ScopeRead)},
+			wantCode: codes.OK,
+		},
+		{
+			name:     "permiso ajeno con TLS",
+			peer:     tlsPeer("10.0.0.3:50000"),
+			req:      &pb.ChunkRequest{ChunkId: "archivo-1", NodeId: "10.0.0.2:50000", Token: signToken(t, tracker, "archivo", "10.0.0.2:50000", security.ScopeRead)},
+			wantCode: codes.PermissionDenied,
+		},
+		{
+			name:     "escritura con permiso ajeno con TLS",
+			peer:     tlsPeer("10.0.0.3:50000"),
+			req:      &pb.StoreChunkRequest{ChunkId: "archivo-1", NodeId: "10.0.0.2:50000", Token: signToken(t, tracker, "archivo", "10.0.0.2:50000", security.ScopeWrite)},
+			wantCode: codes.PermissionDenied,
+		},
+		{
+			name:     "orden para este nodo",
+			req:      &pb.DeleteChunkRequest{ChunkId: "archivo-1", Token: signToken(t, tracker, "archivo", "10.0.0.1:50000", security.ScopeDelete)},
+			wantCode: codes.OK,
+		},
+		{
+			name:     "orden para otro nodo",
+			req:      &pb.DeleteChunkRequest{ChunkId: "archivo-1", Token: signToken(t, tracker, "archivo", "10.0.0.2:50000", security.ScopeDelete)},
+			wantCode: codes.PermissionDenied,
+		},
+		{
+			name:     "método sin permiso conocido",
+			req:      &pb.ChunkKey{FileId: "archivo", Index: 1},
+			wantCode: codes.PermissionDenied,
+		},
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) { return nil, nil }
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			if tt.peer != nil {
+				ctx = peer.NewContext(ctx, tt.peer)
+			}
+			_, err := server.accessInterceptor(ctx, tt.req, &grpc.UnaryServerInfo{FullMethod: "/prueba"}, handler)
+			if status.Code(err) != tt.wantCode {
+				t.Errorf("accessInterceptor devolvió %v, se esperaba %v", err, tt.wantCode)
+			}
+		})
+	}
+}
+
+func TestPinTrackerKey(t *testing.T) {
+	configured, sent := newTracker(t).PublicKey(), newTracker(t).PublicKey()
+	tests := []struct {
+		name       string
+		configured []byte
+		peer       *peer.Peer
+		want       []byte
+	}{
+		{name: "sin TLS", peer: &peer.Peer{}, want: nil},
+		{name: "TLS con el certificado del tracker", peer: tlsPeer(security.TrackerIdentity), want: sent},
+		{name: "TLS con el certificado de un nodo", peer: tlsPeer("10.0.0.2:50000"), want: nil},
+		{name: "clave configurada sin TLS", configured: configured, peer: &peer.Peer{}, want: configured},
+		{name: "clave configurada con TLS", configured: configured, peer: tlsPeer(security.TrackerIdentity), want: configured},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := NewNodeServer("10.0.0.1:50000", Config{TrackerKey: tt.configured})
+			server.pinTrackerKey(sent, tt.peer)
+			if !bytes.Equal(server.trackerKey, tt.want) {
+				t.Errorf("clave del tracker %x, se esperaba %x", server.trackerKey, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,175 @@
+package node
+
+import (
+	"log"
+	"math/rand"
+	"sort"
+	"sync"
+	"time"
+)
+
+// Parámetros del algoritmo de choking (tit-for-tat).
+const (
+	DefaultUnchokeSlots = 4                // Cantidad de peers a los que se sirve simultáneamente.
+	rechokeInterval     = 10 * time.Second // Cada cuánto se recalcula el conjunto de peers unchoked.
+	optimisticInterval  = 30 * time.Second // Cada cuánto se rota el slot de unchoke optimista.
+	interestTimeout     = 30 * time.Second // Tiempo tras el cual un peer sin solicitudes deja de estar interesado.
+)
+
+// peerStats acumula el tráfico intercambiado con un peer.
+type peerStats struct {
+	uploaded    int64     // Bytes que le hemos enviado.
+	downloaded  int64     // Bytes que nos ha enviado.
+	recentDown  int64     // Bytes recibidos durante la ronda actual.
+	lastRequest time.Time // Última vez que nos solicitó un chunk.
+}
+
+// chokeManager limita la cantidad de peers a los que se sirven chunks y
+// prioriza a los que más nos han enviado recientemente.
+type chokeManager struct {
+	mu             sync.Mutex
+	slots          int                   // Máximo de peers unchoked, incluyendo el optimista.
+	peers          map[string]*peerStats // Estadísticas por peer, indexadas por node_id.
+	unchoked       map[string]bool       // Peers a los que se les sirve actualmente.
+	optimistic     string                // Peer del slot optimista.
+	lastOptimistic time.Time             // Momento de la última rotación del slot optimista.
+}
+
+// newChokeManager crea un chokeManager con la cantidad de slots indicada.
+func newChokeManager(slots int) *chokeManager {
+	if slots <= 0 {
+		slots = DefaultUnchokeSlots
+	}
+	return &chokeManager{
+		slots:    slots,
+		peers:    make(map[string]*peerStats),
+		unchoked: make(map[string]bool),
+	}
+}
+
+// peer obtiene (o crea) las estadísticas de un peer. Debe llamarse con mu tomado.
+func (c *chokeManager) peer(peerID string) *peerStats {
+	p, exists := c.peers[peerID]
+	if !exists {
+		p = &peerStats{}
+		c.peers[peerID] = p
+	}
+	return p
+}
+
+// allowUpload registra el interés del peer y decide si se le puede servir un chunk.
+func (c *chokeManager) allowUpload(peerID string) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.peer(peerID).lastRequest = time.Now()
+
+	if c.unchoked[peerID] {
+		return true
+	}
+
+	// Mientras haya slots libres no hace falta esperar a la siguiente ronda
+	if len(c.unchoked) < c.slots {
+		c.unchoked[peerID] = true
+		log.Printf("Peer %s unchoked (slot libre)", peerID)
+		return true
+	}
+
+	return false
+}
+
+// recordUpload suma los bytes enviados a un peer.
+func (c *chokeManager) recordUpload(peerID string, n int) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.peer(peerID).uploaded += int64(n)
+}
+
+// recordDownload suma los bytes recibidos de un peer.
+func (c *chokeManager) recordDownload(peerID string, n int) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	p := c.peer(peerID)
+	p.downloaded += int64(n)
+	p.recentDown += int64(n)
+}
+
+// rechoke recalcula los peers unchoked: los que más nos enviaron en la última
+// ronda ocupan los slots regulares y el restante se asigna de forma optimista.
+func (c *chokeManager) rechoke() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	now := time.Now()
+
+	// Solo compiten los peers que siguen interesados
+	var interested []string
+	for peerID, p := range c.peers {
+		if now.Sub(p.lastRequest) <= interestTimeout {
+			interested = append(interested, peerID)
+		}
+	}
+
+	sort.Slice(interested, func(i, j int) bool {
+		return c.peers[interested[i]].recentDown > c.peers[interested[j]].recentDown
+	})
+
+	unchoked := make(map[string]bool)
+	regular := c.slots - 1 // Un slot se reserva para el unchoke optimista
+	for _, peerID := range interested {
+		if len(unchoked) >= regular {
+			break
+		}
+		unchoked[peerID] = true
+	}
+
+	// Rotar el slot optimista si expiró o si el peer ya no lo necesita
+	if c.optimistic == "" || unchoked[c.optimistic] || now.Sub(c.lastOptimistic) >= optimisticInterval ||
+		now.Sub(c.peers[c.optimistic].lastRequest) > interestTimeout {
+		var candidates []string
+		for _, peerID := range interested {
+			if !unchoked[peerID] {
+				candidates = append(candidates, peerID)
+			}
+		}
+		c.optimistic = ""
+		if len(candidates) > 0 {
+			c.optimistic = candidates[rand.Intn(len(candidates))]
+			c.lastOptimistic = now
+		}
+	}
+	if c.optimistic != "" {
+		unchoked[c.optimistic] = true
+	}
+
+	// Reiniciar los contadores de la ronda
+	for _, p := range c.peers {
+		p.recentDown = 0
+	}
+
+	c.unchoked = unchoked
+	if len(interested) > 0 {
+		log.Printf("Rechoke: %d peers interesados, unchoked: %v (optimista: %s)", len(interested), keys(unchoked), c.optimistic)
+	}
+}
+
+// run ejecuta el rechoke periódicamente.
+func (c *chokeManager) run() {
+	ticker := time.NewTicker(rechokeInterval)
+	defer ticker.Stop()
+
+	for range ticker.C {
+		c.rechoke()
+	}
+}
+
+// keys devuelve las claves de un mapa de peers.
+func keys(m map[string]bool) []string {
+	var result []string
+	for k := range m {
+		result = append(result, k)
+	}
+	return result
+}
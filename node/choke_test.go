@@ -0,0 +1,128 @@
+package node
+
+import (
+	"slices"
+	"sort"
+	"testing"
+	"time"
+)
+
+func TestAllowUploadFillsFreeSlots(t *testing.T) {
+	c := newChokeManager(2)
+	tests := []struct {
+		peer string
+		want bool
+	}{
+		{peer: "a", want: true},
+		{peer: "b", want: true},
+		{peer: "c", want: false}, // No quedan slots hasta el próximo rechoke.
+		{peer: "a", want: true},  // Un peer unchoked sigue recibiendo.
+	}
+	for _, tt := range tests {
+		if got := c.allowUpload(tt.peer); got != tt.want {
+			t.Errorf("allowUpload(%s) = %v, se esperaba %v", tt.peer, got, tt.want)
+		}
+	}
+	if !c.peers["c"].lastRequest.After(time.Time{}) {
+		t.Error("no se registró el interés del peer rechazado")
+	}
+}
+
+func TestRechoke(t *testing.T) {
+	type peer struct {
+		recentDown int64
+		idle       time.Duration // Tiempo desde su última solicitud.
+	}
+	tests := []struct {
+		name           string
+		slots          int
+		peers          map[string]peer
+		optimistic     string        // Peer optimista de la ronda anterior.
+		optimisticAge  time.Duration // Tiempo desde que se eligió.
+		wantRegular    []string
+		wantOptimistic []string // Candidatos válidos para el slot optimista (vacío = ninguno).
+	}{
+		{
+			name:           "los que más envían ocupan los slots regulares",
+			slots:          3,
+			peers:          map[string]peer{"a": {recentDown: 300}, "b": {recentDown: 200}, "c": {recentDown: 100}, "d": {}},
+			wantRegular:    []string{"a", "b"},
+			wantOptimistic: []string{"c", "d"},
+		},
+		{
+			name:           "los peers sin interés no compiten",
+			slots:          3,
+			peers:          map[string]peer{"a": {recentDown: 300, idle: 2 * interestTimeout}, "b": {recentDown: 200}, "c": {recentDown: 100}},
+			wantRegular:    []string{"b", "c"},
+			wantOptimistic: nil,
+		},
+		{
+			name:        "menos peers que slots",
+			slots:       4,
+			peers:       map[string]peer{"a": {recentDown: 10}},
+			wantRegular: []string{"a"},
+		},
+		{
+			name:           "el optimista vigente se conserva",
+			slots:          2,
+			peers:          map[string]peer{"a": {recentDown: 300}, "b": {}, "c": {}},
+			optimistic:     "c",
+			optimisticAge:  time.Second,
+			wantRegular:    []string{"a"},
+			wantOptimistic: []string{"c"},
+		},
+		{
+			name:           "el optimista sin interés se reemplaza",
+			slots:          2,
+			peers:          map[string]peer{"a": {recentDown: 300}, "b": {}, "c": {idle: 2 * interestTimeout}},
+			optimistic:     "c",
+			optimisticAge:  time.Second,
+			wantRegular:    []string{"a"},
+			wantOptimistic: []string{"b"},
+		},
+		{
+			name:           "el optimista que pasa a regular libera su slot",
+			slots:          2,
+			peers:          map[string]peer{"a": {recentDown: 300}, "b": {}},
+			optimistic:     "a",
+			optimisticAge:  time.Second,
+			wantRegular:    []string{"a"},
+			wantOptimistic: []string{"b"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := newChokeManager(tt.slots)
+			now := time.Now()
+			for id, p := range tt.peers {
+				c.peers[id] = &peerStats{recentDown: p.recentDown, lastRequest: now.Add(-p.idle)}
+			}
+			c.optimistic, c.lastOptimistic = tt.optimistic, now.Add(-tt.optimisticAge)
+
+			c.rechoke()
+
+			if len(tt.wantOptimistic) == 0 {
+				if c.optimistic != "" {
+					t.Errorf("optimista %q, no se esperaba ninguno", c.optimistic)
+				}
+			} else if !slices.Contains(tt.wantOptimistic, c.optimistic) {
+				t.Errorf("optimista %q, se esperaba uno de %v", c.optimistic, tt.wantOptimistic)
+			}
+			want := append([]string(nil), tt.wantRegular...)
+			if c.optimistic != "" {
+				want = append(want, c.optimistic)
+			}
+			got := keys(c.unchoked)
+			sort.Strings(got)
+			sort.Strings(want)
+			if !slices.Equal(got, want) {
+				t.Errorf("unchoked %v, se esperaba %v", got, want)
+			}
+			for id, p := range c.peers {
+				if p.recentDown != 0 {
+					t.Errorf("no se reinició el contador de la ronda del peer %s", id)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,71 @@
+package node
+
+import (
+	"P2P_BitTorrent/pb"
+	"context"
+	"fmt"
+	"log"
+
+	"google.golang.org/grpc"
+)
+
+// FetchChunk solicita un chunk a otro nodo y registra los bytes recibidos para el tit-for-tat
+func (s *nodeServer) FetchChunk(nodeAddress, chunkID string, token *pb.AccessToken) (*pb.ChunkResponse, error) {
+	// Crear una conexión con el nodo destino
+	conn, err := grpc.Dial(nodeAddress, s.credentials.DialOption())
+	if err != nil {
+		return nil, fmt.Errorf("error al conectar con el nodo %s: %v", nodeAddress, err)
+	}
+	defer conn.Close()
+
+	// Crear un cliente gRPC para el nodo
+	client := pb.NewNodeServiceClient(conn)
+
+	// Crear la solicitud del chunk identificándonos para que el peer lleve la cuenta y
+	// con el permiso del tracker para descargar el archivo
+	req := &pb.ChunkRequest{
+		ChunkId: chunkID,
+		NodeId:  s.nodeID,
+		Token:   token,
+	}
+
+	// Reservar el ancho de banda de un chunk antes de pedirlo, para que las descargas
+	// simultáneas no superen los límites; al recibirlo se ajusta según su tamaño real
+	s.download.wait(nodeAddress, ManifestChunkSize)
+
+	// Enviar la solicitud y recibir la respuesta
+	res, err := client.RequestChunk(context.Background(), req)
+	if err != nil {
+		s.download.settle(nodeAddress, -ManifestChunkSize)
+		return nil, fmt.Errorf("error al solicitar chunk %s de %s: %v", chunkID, nodeAddress, err)
+	}
+	if res.ChunkData == nil {
+		s.download.settle(nodeAddress, -ManifestChunkSize)
+		return nil, fmt.Errorf("el nodo %s no tiene el chunk %s: %s", nodeAddress, chunkID, res.Message)
+	}
+
+	s.download.settle(nodeAddress, len(res.ChunkData)-ManifestChunkSize)
+	s.choker.recordDownload(nodeAddress, len(res.ChunkData))
+	log.Printf("Chunk %s recibido desde %s: %s", chunkID, nodeAddress, res.Message)
+	return res, nil
+}
+
+// SendChunkToNode envía un chunk a un nodo específico para que lo almacene
+func (s *nodeServer) SendChunkToNode(nodeAddress string, chunk *pb.StoreChunkRequest) error {
+	conn, err := grpc.Dial(nodeAddress, s.credentials.DialOption())
+	if err != nil {
+		log.Printf("Error al conectar con el nodo %s: %v", nodeAddress, err)
+		return err
+	}
+	defer conn.Close()
+
+	client := pb.NewNodeServiceClient(conn)
+	res, err := client.StoreChunk(context.Background(), chunk)
+	if err != nil {
+		log.Printf("Error al enviar chunk %s a %s: %v", chunk.ChunkId, nodeAddress, err)
+		return err
+	}
+
+	log.Printf("Respuesta al enviar chunk %s a %s: %s", chunk.ChunkId, nodeAddress, res.Message)
+	return nil
+}
@@ -0,0 +1,233 @@
+package node
+
+import (
+	"P2P_BitTorrent/pb"
+	"P2P_BitTorrent/security"
+	"bytes"
+	"errors"
+	"fmt"
+	"io/fs"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// ReadCollection lee los archivos de una carpeta y sus subcarpetas, en orden lexicográfico
+// de sus rutas, y devuelve su contenido concatenado junto con la ruta relativa y la posición
+// de cada uno. Los enlaces simbólicos y los archivos especiales se omiten.
+func ReadCollection(dir string) ([]byte, []*pb.CollectionEntry, error) {
+	info, err := os.Stat(dir)
+	if err != nil {
+		return nil, nil, fmt.Errorf("no se pudo leer la carpeta %s: %v", dir, err)
+	}
+	if !info.IsDir() {
+		return nil, nil, fmt.Errorf("%s no es una carpeta", dir)
+	}
+
+	var content []byte
+	var entries []*pb.CollectionEntry
+	err = filepath.WalkDir(dir, func(filePath string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			return nil
+		}
+		if !d.Type().IsRegular() {
+			log.Printf("Se omite %s: no es un archivo regular", filePath)
+			return nil
+		}
+
+		rel, err := filepath.Rel(dir, filePath)
+		if err != nil {
+			return err
+		}
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+		data, err := os.ReadFile(filePath)
+		if err != nil {
+			return err
+		}
+		entries = append(entries, &pb.CollectionEntry{
+			Path:   filepath.ToSlash(rel),
+			Offset: int64(len(content)),
+			Size:   int64(len(data)),
+			Mode:   uint32(info.Mode().Perm()),
+		})
+		content = append(content, data...)
+		return nil
+	})
+	if err != nil {
+		return nil, nil, fmt.Errorf("no se pudo leer la carpeta %s: %v", dir, err)
+	}
+	if len(entries) == 0 {
+		return nil, nil, fmt.Errorf("la carpeta %s no tiene archivos", dir)
+	}
+	return content, entries, nil
+}
+
+// ContentSizeMb devuelve la cantidad de chunks de ManifestChunkSize que ocupa un contenido;
+// un contenido vacío ocupa un chunk vacío.
+func ContentSizeMb(size int) int {
+	if size == 0 {
+		return 1
+	}
+	return (size + ManifestChunkSize - 1) / ManifestChunkSize
+}
+
+// ContentChunks divide un contenido real en chunks de ManifestChunkSize; el último puede
+// ser más corto.
+func ContentChunks(fileID string, content []byte) []*pb.StoreChunkRequest {
+	chunks := make([]*pb.StoreChunkRequest, ContentSizeMb(len(content)))
+	for i := range chunks {
+		start := min(i*ManifestChunkSize, len(content))
+		end := min(start+ManifestChunkSize, len(content))
+		chunks[i] = &pb.StoreChunkRequest{
+			ChunkId:   ChunkID(&pb.ChunkKey{FileId: fileID, Index: int32(i + 1)}),
+			ChunkData: content[start:end],
+		}
+	}
+	return chunks
+}
+
+// DownloadCollection descarga los archivos indicados de una colección y devuelve su
+// contenido por ruta. Solo se piden los chunks que los contienen, cada uno verificado por
+// separado con su prueba de Merkle; si el archivo usa erasure coding, o se piden todos los
+// archivos, se descarga la colección completa.
+func (s *nodeServer) DownloadCollection(res *pb.JoinResponse, root, key []byte, entries []*pb.CollectionEntry) (map[string][]byte, error) {
+	if res.Manifest == nil || len(res.Manifest.Entries) == 0 {
+		return nil, errors.New("el archivo no es una colección")
+	}
+	if err := checkManifest(res); err != nil {
+		return nil, fmt.Errorf("manifiesto rechazado: %v", err)
+	}
+	chunkSize := res.Manifest.ChunkSize
+	if chunkSize <= 0 {
+		return nil, fmt.Errorf("tamaño de chunk inválido en el manifiesto: %d", chunkSize)
+	}
+
+	if res.StorageMode == StorageErasure || len(entries) == len(res.Manifest.Entries) {
+		content, err := s.DownloadFile(res, root, key)
+		if err != nil {
+			return nil, err
+		}
+		return splitCollection(content, entries)
+	}
+
+	if root == nil {
+		root = res.Manifest.MerkleRoot
+	} else if !bytes.Equal(root, res.Manifest.MerkleRoot) {
+		return nil, errors.New("la raíz de Merkle del manifiesto no coincide con la indicada")
+	}
+
+	// Pedir solo los chunks que cubren los archivos elegidos
+	total := int32(len(res.Manifest.ChunkHashes))
+	needed := make(map[int32]bool)
+	for _, index := range security.CollectionChunks(res.Manifest, entries) {
+		needed[index] = true
+	}
+
+	type result struct {
+		index int32
+		data  []byte
+		err   error
+	}
+	results := make(chan result, len(needed))
+	requested := 0
+	for chunkID, chunkInfo := range res.ChunkMap {
+		index := chunkInfo.Key.GetIndex()
+		if !needed[index] {
+			continue
+		}
+		requested++
+		go func(chunkID string, chunkInfo *pb.ChunkInfo) {
+			data, err := s.fetchFromAny(chunkInfo.Nodes, chunkID, res.AccessToken, dataChunkCheck(res, root, index))
+			results <- result{index: index, data: data, err: err}
+		}(chunkID, chunkInfo)
+	}
+
+	chunks := make(map[int32][]byte)
+	for i := 0; i < requested; i++ {
+		r := <-results
+		if r.err != nil {
+			return nil, r.err
+		}
+		chunks[r.index] = r.data
+	}
+	for index := range needed {
+		if _, exists := chunks[index]; !exists {
+			return nil, fmt.Errorf("el tracker no indicó nodos para el chunk %d", index)
+		}
+	}
+	if res.Manifest.Encrypted {
+		var err error
+		if chunks, err = decryptChunks(key, res.FileId, chunks); err != nil {
+			return nil, err
+		}
+	}
+
+	// Las posiciones de los archivos suponen que todos los chunks salvo el último miden chunkSize
+	for index, data := range chunks {
+		if index < total && int64(len(data)) != chunkSize {
+			return nil, fmt.Errorf("el chunk %d mide %d bytes y debería medir %d", index, len(data), chunkSize)
+		}
+	}
+
+	files := make(map[string][]byte, len(entries))
+	for _, entry := range entries {
+		data := make([]byte, 0, entry.Size)
+		for pos := entry.Offset; pos < entry.Offset+entry.Size; {
+			index := int32(pos/chunkSize) + 1
+			within := pos - int64(index-1)*chunkSize
+			n := min(entry.Offset+entry.Size-pos, int64(len(chunks[index]))-within)
+			if n <= 0 {
+				return nil, fmt.Errorf("el contenido descargado no incluye el archivo %s", entry.Path)
+			}
+			data = append(data, chunks[index][within:within+n]...)
+			pos += n
+		}
+		files[entry.Path] = data
+	}
+	return files, nil
+}
+
+// splitCollection separa los archivos de una colección a partir de su contenido completo.
+func splitCollection(content []byte, entries []*pb.CollectionEntry) (map[string][]byte, error) {
+	files := make(map[string][]byte, len(entries))
+	for _, entry := range entries {
+		if entry.Offset+entry.Size > int64(len(content)) {
+			return nil, fmt.Errorf("el contenido descargado no incluye el archivo %s", entry.Path)
+		}
+		files[entry.Path] = content[entry.Offset : entry.Offset+entry.Size]
+	}
+	return files, nil
+}
+
+// WriteCollection guarda los archivos de una colección dentro de dir, recreando sus
+// carpetas, y devuelve los bytes escritos.
+func WriteCollection(dir string, entries []*pb.CollectionEntry, files map[string][]byte) (int64, error) {
+	var written int64
+	for _, entry := range entries {
+		// Las rutas ya se validaron con el manifiesto; se comprueba de nuevo que no salgan de dir
+		target := filepath.Join(dir, filepath.FromSlash(entry.Path))
+		if rel, err := filepath.Rel(dir, target); err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+			return written, fmt.Errorf("la ruta %s sale de la carpeta de descarga", entry.Path)
+		}
+
+		if err := os.MkdirAll(filepath.Dir(target), 0755); err != nil {
+			return written, err
+		}
+		mode := fs.FileMode(entry.Mode).Perm()
+		if mode == 0 {
+			mode = 0644
+		}
+		if err := os.WriteFile(target, files[entry.Path], mode); err != nil {
+			return written, err
+		}
+		written += int64(len(files[entry.Path]))
+	}
+	return written, nil
+}
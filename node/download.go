@@ -0,0 +1,201 @@
+package node
+
+import (
+	"P2P_BitTorrent/pb"
+	"bytes"
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"log"
+	"sort"
+	"sync"
+)
+
+// Modos de almacenamiento de un archivo, según los reporta el tracker.
+const (
+	StorageReplication = "replication"
+	StorageErasure     = "ec"
+)
+
+// chunkCheck verifica un chunk recibido antes de aceptarlo.
+type chunkCheck func(chunk *pb.ChunkResponse) error
+
+// dataChunkCheck devuelve la verificación de un chunk de datos: con la raíz de Merkle se
+// comprueba la prueba que envía el nodo, de modo que cada chunk se verifica por separado
+// apenas llega, y con el manifiesto se compara además su hash.
+func dataChunkCheck(res *pb.JoinResponse, root []byte, index int32) chunkCheck {
+	expected := expectedHash(res, index)
+	return func(chunk *pb.ChunkResponse) error {
+		if root != nil && !VerifyMerkleProof(root, index, chunk.ChunkData, chunk.Proof) {
+			return errors.New("la prueba de Merkle no coincide con la raíz del archivo")
+		}
+		if sum := sha256.Sum256(chunk.ChunkData); expected != nil && !bytes.Equal(sum[:], expected) {
+			return errors.New("no coincide con el manifiesto")
+		}
+		return nil
+	}
+}
+
+// fetchFromAny solicita un chunk a los nodos que lo almacenan, probando el siguiente si uno lo
+// rechaza o entrega datos que no pasan la verificación
+func (s *nodeServer) fetchFromAny(nodeAddresses []string, chunkID string, token *pb.AccessToken, check chunkCheck) ([]byte, error) {
+	res, err := s.fetchResponse(nodeAddresses, chunkID, token, check)
+	if err != nil {
+		return nil, err
+	}
+	return res.ChunkData, nil
+}
+
+// fetchResponse es como fetchFromAny, pero devuelve la respuesta completa con la prueba de
+// Merkle del chunk.
+func (s *nodeServer) fetchResponse(nodeAddresses []string, chunkID string, token *pb.AccessToken, check chunkCheck) (*pb.ChunkResponse, error) {
+	for _, nodeAddress := range nodeAddresses {
+		res, err := s.FetchChunk(nodeAddress, chunkID, token)
+		if err != nil {
+			log.Printf("%v", err)
+			continue
+		}
+		if check != nil {
+			if err := check(res); err != nil {
+				log.Printf("Chunk %s recibido de %s rechazado: %v", chunkID, nodeAddress, err)
+				continue
+			}
+		}
+		return res, nil
+	}
+	return nil, fmt.Errorf("no se pudo obtener el chunk %s de ningún nodo", chunkID)
+}
+
+// DownloadFile descarga los chunks de un archivo a partir de la respuesta del tracker
+// y devuelve su contenido en orden, reconstruyendo las franjas incompletas si el archivo
+// usa erasure coding. Si se conoce la raíz de Merkle del archivo (por ejemplo, de un
+// enlace), cada chunk de datos se verifica contra ella; si no, se usa la del manifiesto.
+// Los archivos cifrados se descifran con key una vez verificados.
+func (s *nodeServer) DownloadFile(res *pb.JoinResponse, root, key []byte) ([]byte, error) {
+	if res.Manifest != nil {
+		if err := checkManifest(res); err != nil {
+			return nil, fmt.Errorf("manifiesto rechazado: %v", err)
+		}
+		if root == nil {
+			root = res.Manifest.MerkleRoot
+		} else if !bytes.Equal(root, res.Manifest.MerkleRoot) {
+			return nil, errors.New("la raíz de Merkle del manifiesto no coincide con la indicada")
+		}
+	}
+
+	if res.StorageMode == StorageErasure {
+		return s.downloadErasure(res, root, key)
+	}
+
+	// Descargar todos los chunks de forma concurrente
+	type result struct {
+		index int32
+		data  []byte
+		err   error
+	}
+	results := make(chan result, len(res.ChunkMap))
+	for chunkID, chunkInfo := range res.ChunkMap {
+		go func(chunkID string, chunkInfo *pb.ChunkInfo) {
+			index := chunkInfo.Key.GetIndex()
+			data, err := s.fetchFromAny(chunkInfo.Nodes, chunkID, res.AccessToken, dataChunkCheck(res, root, index))
+			results <- result{index: index, data: data, err: err}
+		}(chunkID, chunkInfo)
+	}
+
+	chunks := make(map[int32][]byte)
+	for range res.ChunkMap {
+		r := <-results
+		if r.err != nil {
+			return nil, r.err
+		}
+		chunks[r.index] = r.data
+	}
+
+	return joinVerified(res, chunks, key)
+}
+
+// downloadErasure descarga cada franja pidiendo todos sus shards en paralelo y la
+// reconstruye en cuanto hay suficientes.
+func (s *nodeServer) downloadErasure(res *pb.JoinResponse, root, key []byte) ([]byte, error) {
+	k := int(res.DataShards)
+	chunks := make(map[int32][]byte)
+
+	for stripe, shardIDs := range stripeLayout(res) {
+		shards := make([][]byte, len(shardIDs))
+		known := make([]bool, k)
+
+		var mu sync.Mutex
+		var wg sync.WaitGroup
+		for i, chunkID := range shardIDs {
+			if chunkID == "" {
+				continue
+			}
+			if i < k {
+				known[i] = true
+			}
+			wg.Add(1)
+			go func(i int, chunkID string) {
+				defer wg.Done()
+				var check chunkCheck // Los shards de paridad se validan al reconstruir
+				if i < k {
+					check = dataChunkCheck(res, root, int32(int(stripe)*k+i+1))
+				}
+				data, err := s.fetchFromAny(res.ChunkMap[chunkID].Nodes, chunkID, res.AccessToken, check)
+				if err != nil {
+					return
+				}
+				mu.Lock()
+				shards[i] = data
+				mu.Unlock()
+			}(i, chunkID)
+		}
+		wg.Wait()
+
+		data, err := ReconstructStripe(shards, k, known)
+		if err != nil {
+			return nil, fmt.Errorf("no se pudo reconstruir la franja %d: %v", stripe, err)
+		}
+		for i := 0; i < k; i++ {
+			if known[i] {
+				chunks[int32(int(stripe)*k+i+1)] = data[i]
+			}
+		}
+	}
+
+	return joinVerified(res, chunks, key)
+}
+
+// joinVerified verifica los chunks contra el manifiesto del archivo, si el tracker lo
+// devolvió, los descifra si el archivo está cifrado y los concatena en orden.
+func joinVerified(res *pb.JoinResponse, chunks map[int32][]byte, key []byte) ([]byte, error) {
+	if res.Manifest != nil {
+		if err := verifyManifest(res, chunks); err != nil {
+			return nil, fmt.Errorf("el archivo no coincide con su manifiesto firmado: %v", err)
+		}
+		if res.Manifest.Encrypted {
+			var err error
+			if chunks, err = decryptChunks(key, res.FileId, chunks); err != nil {
+				return nil, err
+			}
+		}
+	}
+	return joinChunks(chunks)
+}
+
+// joinChunks concatena los chunks en orden y verifica que no falte ninguno.
+func joinChunks(chunks map[int32][]byte) ([]byte, error) {
+	var indexes []int
+	for index := range chunks {
+		indexes = append(indexes, int(index))
+	}
+	sort.Ints(indexes)
+
+	var content []byte
+	for i, index := range indexes {
+		if index != i+1 {
+			return nil, fmt.Errorf("falta el chunk %d del archivo", i+1)
+		}
+		content = append(content, chunks[int32(index)]...)
+	}
+	return content, nil
+}
@@ -0,0 +1,78 @@
+package node
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"errors"
+	"fmt"
+
+	"P2P_BitTorrent/pb"
+)
+
+// Tamaño de la clave AES-256 con la que se cifra cada archivo.
+const FileKeySize = 32
+
+// NewFileKey genera una clave aleatoria para cifrar un archivo.
+func NewFileKey() ([]byte, error) {
+	key := make([]byte, FileKeySize)
+	if _, err := rand.Read(key); err != nil {
+		return nil, err
+	}
+	return key, nil
+}
+
+// fileCipher construye el cifrador AES-GCM de un archivo.
+func fileCipher(key []byte) (cipher.AEAD, error) {
+	if len(key) != FileKeySize {
+		return nil, fmt.Errorf("la clave del archivo debe tener %d bytes", FileKeySize)
+	}
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+// EncryptChunks cifra los chunks de datos de un archivo antes de enviarlos a los nodos.
+// Cada chunk lleva su propio nonce aleatorio al principio, y su chunk_id como dato
+// asociado para que un nodo no pueda hacerlo pasar por otro chunk del archivo.
+func EncryptChunks(key []byte, chunks []*pb.StoreChunkRequest) error {
+	aead, err := fileCipher(key)
+	if err != nil {
+		return err
+	}
+	for _, chunk := range chunks {
+		nonce := make([]byte, aead.NonceSize())
+		if _, err := rand.Read(nonce); err != nil {
+			return err
+		}
+		chunk.ChunkData = aead.Seal(nonce, nonce, chunk.ChunkData, []byte(chunk.ChunkId))
+	}
+	return nil
+}
+
+// decryptChunks descifra los chunks de datos descargados de un archivo cifrado.
+func decryptChunks(key []byte, fileID string, chunks map[int32][]byte) (map[int32][]byte, error) {
+	if key == nil {
+		return nil, errors.New("el archivo está cifrado y no se tiene su clave")
+	}
+	aead, err := fileCipher(key)
+	if err != nil {
+		return nil, err
+	}
+
+	plain := make(map[int32][]byte, len(chunks))
+	for index, data := range chunks {
+		if len(data) < aead.NonceSize() {
+			return nil, fmt.Errorf("el chunk %d cifrado está truncado", index)
+		}
+		chunkID := ChunkID(&pb.ChunkKey{FileId: fileID, Index: index})
+		nonce, ciphertext := data[:aead.NonceSize()], data[aead.NonceSize():]
+		plain[index], err = aead.Open(nil, nonce, ciphertext, []byte(chunkID))
+		if err != nil {
+			return nil, fmt.Errorf("no se pudo descifrar el chunk %d: clave incorrecta o datos alterados", index)
+		}
+	}
+	return plain, nil
+}
@@ -0,0 +1,339 @@
+package node
+
+import (
+	"P2P_BitTorrent/pb"
+	"encoding/binary"
+	"errors"
+	"fmt"
+)
+
+// Implementación de Reed-Solomon sobre GF(2^8) con una matriz de codificación sistemática:
+// los k shards de datos se guardan tal cual y los m shards de paridad son combinaciones
+// lineales de ellos, de modo que cualquier subconjunto de k shards permite reconstruir la franja.
+
+// Polinomio generador del campo (x^8 + x^4 + x^3 + x^2 + 1).
+const gfPolynomial = 0x11d
+
+var (
+	gfExp [512]byte // gfExp[i] = 2^i, duplicada para evitar el módulo en gfMul.
+	gfLog [256]byte // gfLog[gfExp[i]] = i.
+)
+
+func init() {
+	x := 1
+	for i := 0; i < 255; i++ {
+		gfExp[i] = byte(x)
+		gfLog[x] = byte(i)
+		x <<= 1
+		if x&0x100 != 0 {
+			x ^= gfPolynomial
+		}
+	}
+	for i := 255; i < 512; i++ {
+		gfExp[i] = gfExp[i-255]
+	}
+}
+
+// gfMul multiplica dos elementos del campo.
+func gfMul(a, b byte) byte {
+	if a == 0 || b == 0 {
+		return 0
+	}
+	return gfExp[int(gfLog[a])+int(gfLog[b])]
+}
+
+// gfInv devuelve el inverso multiplicativo de un elemento distinto de cero.
+func gfInv(a byte) byte {
+	return gfExp[255-int(gfLog[a])]
+}
+
+// gfPow eleva a a la potencia n.
+func gfPow(a byte, n int) byte {
+	if n == 0 {
+		return 1
+	}
+	if a == 0 {
+		return 0
+	}
+	return gfExp[(int(gfLog[a])*n)%255]
+}
+
+// matrix es una matriz de elementos de GF(2^8).
+type matrix [][]byte
+
+func newMatrix(rows, cols int) matrix {
+	m := make(matrix, rows)
+	for i := range m {
+		m[i] = make([]byte, cols)
+	}
+	return m
+}
+
+// multiply devuelve el producto m × other.
+func (m matrix) multiply(other matrix) matrix {
+	result := newMatrix(len(m), len(other[0]))
+	for r := range m {
+		for c := range other[0] {
+			var v byte
+			for i := range other {
+				v ^= gfMul(m[r][i], other[i][c])
+			}
+			result[r][c] = v
+		}
+	}
+	return result
+}
+
+// invert devuelve la inversa de una matriz cuadrada usando eliminación de Gauss-Jordan.
+func (m matrix) invert() (matrix, error) {
+	n := len(m)
+	work := newMatrix(n, 2*n)
+	for r := 0; r < n; r++ {
+		copy(work[r], m[r])
+		work[r][n+r] = 1
+	}
+
+	for col := 0; col < n; col++ {
+		// Buscar una fila con pivote distinto de cero
+		pivot := -1
+		for r := col; r < n; r++ {
+			if work[r][col] != 0 {
+				pivot = r
+				break
+			}
+		}
+		if pivot < 0 {
+			return nil, errors.New("matriz singular")
+		}
+		work[col], work[pivot] = work[pivot], work[col]
+
+		// Normalizar la fila del pivote
+		inv := gfInv(work[col][col])
+		for c := range work[col] {
+			work[col][c] = gfMul(work[col][c], inv)
+		}
+
+		// Eliminar la columna en las demás filas
+		for r := 0; r < n; r++ {
+			if r == col || work[r][col] == 0 {
+				continue
+			}
+			factor := work[r][col]
+			for c := range work[r] {
+				work[r][c] ^= gfMul(factor, work[col][c])
+			}
+		}
+	}
+
+	result := newMatrix(n, n)
+	for r := range result {
+		copy(result[r], work[r][n:])
+	}
+	return result, nil
+}
+
+// encodingMatrix construye la matriz (k+m)×k cuyas primeras k filas son la identidad.
+func encodingMatrix(k, m int) (matrix, error) {
+	vandermonde := newMatrix(k+m, k)
+	for r := range vandermonde {
+		for c := range vandermonde[r] {
+			vandermonde[r][c] = gfPow(byte(r), c)
+		}
+	}
+
+	topInverse, err := vandermonde[:k].invert()
+	if err != nil {
+		return nil, err
+	}
+	return vandermonde.multiply(topInverse), nil
+}
+
+// frameShard agrega el largo original al inicio del chunk y lo rellena hasta size bytes,
+// para que todos los shards de una franja tengan el mismo tamaño.
+func frameShard(data []byte, size int) []byte {
+	framed := make([]byte, size)
+	binary.BigEndian.PutUint32(framed, uint32(len(data)))
+	copy(framed[4:], data)
+	return framed
+}
+
+// unframeShard recupera el chunk original de un shard de datos reconstruido.
+func unframeShard(framed []byte) ([]byte, error) {
+	if len(framed) < 4 {
+		return nil, errors.New("shard demasiado corto")
+	}
+	n := binary.BigEndian.Uint32(framed)
+	if int(n) > len(framed)-4 {
+		return nil, fmt.Errorf("largo de shard inválido: %d", n)
+	}
+	return framed[4 : 4+n], nil
+}
+
+// EncodeStripe calcula los m shards de paridad de una franja de k chunks de datos.
+// Los chunks de datos pueden tener distinto largo; los que falten al final del archivo
+// se pasan como nil y cuentan como chunks vacíos.
+func EncodeStripe(data [][]byte, m int) ([][]byte, error) {
+	k := len(data)
+	enc, err := encodingMatrix(k, m)
+	if err != nil {
+		return nil, err
+	}
+
+	size := 4
+	for _, d := range data {
+		if len(d)+4 > size {
+			size = len(d) + 4
+		}
+	}
+
+	framed := make([][]byte, k)
+	for i, d := range data {
+		framed[i] = frameShard(d, size)
+	}
+
+	parity := make([][]byte, m)
+	for p := 0; p < m; p++ {
+		parity[p] = make([]byte, size)
+		row := enc[k+p]
+		for i := 0; i < k; i++ {
+			for b := 0; b < size; b++ {
+				parity[p][b] ^= gfMul(row[i], framed[i][b])
+			}
+		}
+	}
+	return parity, nil
+}
+
+// ReconstructStripe recupera los k chunks de datos de una franja a partir de los shards
+// disponibles. shards tiene k+m posiciones (primero los datos, luego la paridad) y nil en
+// las que no se pudieron obtener; known indica los shards de datos que existen en el
+// archivo (los que no existen cuentan como vacíos). Se necesitan al menos k shards.
+func ReconstructStripe(shards [][]byte, k int, known []bool) ([][]byte, error) {
+	m := len(shards) - k
+
+	// Si todos los datos están disponibles no hace falta decodificar
+	complete := true
+	for i := 0; i < k; i++ {
+		if known[i] && shards[i] == nil {
+			complete = false
+			break
+		}
+	}
+	if complete {
+		data := make([][]byte, k)
+		for i := 0; i < k; i++ {
+			data[i] = shards[i]
+		}
+		return data, nil
+	}
+
+	// El tamaño de los shards se conoce por cualquier shard de paridad
+	size := 0
+	for p := k; p < k+m; p++ {
+		if shards[p] != nil {
+			size = len(shards[p])
+			break
+		}
+	}
+	if size == 0 {
+		return nil, errors.New("no hay shards de paridad para reconstruir la franja")
+	}
+
+	enc, err := encodingMatrix(k, m)
+	if err != nil {
+		return nil, err
+	}
+
+	// Tomar k shards disponibles y las filas correspondientes de la matriz
+	sub := newMatrix(0, 0)
+	var available [][]byte
+	for i := 0; i < k+m && len(available) < k; i++ {
+		switch {
+		case i < k && !known[i]:
+			available = append(available, frameShard(nil, size))
+		case shards[i] != nil && i < k:
+			available = append(available, frameShard(shards[i], size))
+		case shards[i] != nil:
+			available = append(available, shards[i])
+		default:
+			continue
+		}
+		sub = append(sub, enc[i])
+	}
+	if len(available) < k {
+		return nil, fmt.Errorf("solo hay %d de %d shards necesarios", len(available), k)
+	}
+
+	decode, err := sub.invert()
+	if err != nil {
+		return nil, err
+	}
+
+	data := make([][]byte, k)
+	for i := 0; i < k; i++ {
+		if !known[i] {
+			continue
+		}
+		if shards[i] != nil {
+			data[i] = shards[i]
+			continue
+		}
+		framed := make([]byte, size)
+		for j := 0; j < k; j++ {
+			for b := 0; b < size; b++ {
+				framed[b] ^= gfMul(decode[i][j], available[j][b])
+			}
+		}
+		if data[i], err = unframeShard(framed); err != nil {
+			return nil, err
+		}
+	}
+	return data, nil
+}
+
+// stripeLayout agrupa los chunks de una respuesta del tracker por franja, ordenados por shard.
+func stripeLayout(res *pb.JoinResponse) map[int32][]string {
+	n := int(res.DataShards + res.ParityShards)
+	stripes := make(map[int32][]string)
+	for chunkID, info := range res.ChunkMap {
+		if stripes[info.Stripe] == nil {
+			stripes[info.Stripe] = make([]string, n)
+		}
+		if int(info.Shard) < n {
+			stripes[info.Stripe][info.Shard] = chunkID
+		}
+	}
+	return stripes
+}
+
+// BuildParityChunks calcula los shards de paridad de cada franja asignada por el tracker
+// a partir de los chunks de datos del archivo.
+func BuildParityChunks(res *pb.JoinResponse, chunks []*pb.StoreChunkRequest) ([]*pb.StoreChunkRequest, error) {
+	k, m := int(res.DataShards), int(res.ParityShards)
+
+	var parityChunks []*pb.StoreChunkRequest
+	for stripe, shardIDs := range stripeLayout(res) {
+		data := make([][]byte, k)
+		for i := 0; i < k; i++ {
+			if shardIDs[i] == "" {
+				continue // Chunk inexistente al final del archivo
+			}
+			chunk := FindChunk(chunks, shardIDs[i])
+			if chunk == nil {
+				return nil, fmt.Errorf("falta el chunk %s de la franja %d", shardIDs[i], stripe)
+			}
+			data[i] = chunk.ChunkData
+		}
+
+		parity, err := EncodeStripe(data, m)
+		if err != nil {
+			return nil, err
+		}
+		for p := 0; p < m; p++ {
+			if shardIDs[k+p] != "" {
+				parityChunks = append(parityChunks, &pb.StoreChunkRequest{ChunkId: shardIDs[k+p], ChunkData: parity[p]})
+			}
+		}
+	}
+	return parityChunks, nil
+}
@@ -0,0 +1,145 @@
+package node
+
+import (
+	"bytes"
+	"fmt"
+	"math/rand"
+	"testing"
+)
+
+// subsets devuelve todos los subconjuntos de size elementos de positions.
+func subsets(positions []int, size int) [][]int {
+	if size == 0 {
+		return [][]int{nil}
+	}
+	if len(positions) < size {
+		return nil
+	}
+	var result [][]int
+	for _, rest := range subsets(positions[1:], size-1) {
+		result = append(result, append([]int{positions[0]}, rest...))
+	}
+	return append(result, subsets(positions[1:], size)...)
+}
+
+// randomChunk devuelve un chunk de size bytes con contenido pseudoaleatorio.
+func randomChunk(rng *rand.Rand, size int) []byte {
+	data := make([]byte, size)
+	rng.Read(data)
+	return data
+}
+
+func TestReconstructStripeFromAnyKShards(t *testing.T) {
+	tests := []struct {
+		k, m  int
+		sizes []int // Largo de cada chunk de datos.
+	}{
+		{k: 2, m: 1, sizes: []int{1000, 1000}},
+		{k: 3, m: 2, sizes: []int{4096, 4096, 4096}},
+		{k: 4, m: 2, sizes: []int{5000, 3000, 1, 0}},
+		{k: 1, m: 2, sizes: []int{777}},
+		{k: 5, m: 3, sizes: []int{2048, 2048, 2048, 2048, 100}},
+	}
+	rng := rand.New(rand.NewSource(1))
+	for _, tt := range tests {
+		t.Run(fmt.Sprintf("%d+%d", tt.k, tt.m), func(t *testing.T) {
+			data := make([][]byte, tt.k)
+			for i, size := range tt.sizes {
+				data[i] = randomChunk(rng, size)
+			}
+			parity, err := EncodeStripe(data, tt.m)
+			if err != nil {
+				t.Fatalf("EncodeStripe: %v", err)
+			}
+			all := append(append([][]byte(nil), data...), parity...)
+			known := make([]bool, tt.k)
+			for i := range known {
+				known[i] = true
+			}
+
+			positions := make([]int, tt.k+tt.m)
+			for i := range positions {
+				positions[i] = i
+			}
+			for _, subset := range subsets(positions, tt.k) {
+				shards := make([][]byte, tt.k+tt.m)
+				for _, i := range subset {
+					shards[i] = all[i]
+				}
+				got, err := ReconstructStripe(shards, tt.k, known)
+				if err != nil {
+					t.Fatalf("shards %v: %v", subset, err)
+				}
+				for i := range data {
+					if !bytes.Equal(got[i], data[i]) {
+						t.Errorf("shards %v: el chunk %d reconstruido no coincide con el original", subset, i)
+					}
+				}
+			}
+		})
+	}
+}
+
+func TestReconstructShortLastStripe(t *testing.T) {
+	tests := []struct {
+		k, m     int
+		existing int // Chunks de datos que existen en la última franja.
+	}{
+		{k: 4, m: 2, existing: 2},
+		{k: 3, m: 1, existing: 1},
+		{k: 5, m: 3, existing: 4},
+	}
+	rng := rand.New(rand.NewSource(2))
+	for _, tt := range tests {
+		t.Run(fmt.Sprintf("%d+%d-con-%d", tt.k, tt.m, tt.existing), func(t *testing.T) {
+			data := make([][]byte, tt.k)
+			known := make([]bool, tt.k)
+			for i := 0; i < tt.existing; i++ {
+				data[i] = randomChunk(rng, 3000-i*700)
+				known[i] = true
+			}
+			parity, err := EncodeStripe(data, tt.m)
+			if err != nil {
+				t.Fatalf("EncodeStripe: %v", err)
+			}
+			all := append(append([][]byte(nil), data...), parity...)
+
+			// Los chunks inexistentes cuentan como shards conocidos: alcanza con k menos esos
+			var positions []int
+			for i := range all {
+				if i >= tt.k || known[i] {
+					positions = append(positions, i)
+				}
+			}
+			for _, subset := range subsets(positions, tt.existing) {
+				shards := make([][]byte, tt.k+tt.m)
+				for _, i := range subset {
+					shards[i] = all[i]
+				}
+				got, err := ReconstructStripe(shards, tt.k, known)
+				if err != nil {
+					t.Fatalf("shards %v: %v", subset, err)
+				}
+				for i := 0; i < tt.k; i++ {
+					if !bytes.Equal(got[i], data[i]) {
+						t.Errorf("shards %v: el chunk %d reconstruido no coincide con el original", subset, i)
+					}
+				}
+			}
+		})
+	}
+}
+
+func TestReconstructStripeNeedsKShards(t *testing.T) {
+	rng := rand.New(rand.NewSource(3))
+	data := [][]byte{randomChunk(rng, 100), randomChunk(rng, 100), randomChunk(rng, 100)}
+	parity, err := EncodeStripe(data, 2)
+	if err != nil {
+		t.Fatalf("EncodeStripe: %v", err)
+	}
+
+	shards := [][]byte{data[0], nil, nil, parity[0], nil}
+	if _, err := ReconstructStripe(shards, 3, []bool{true, true, true}); err == nil {
+		t.Error("se reconstruyó la franja con 2 de 3 shards")
+	}
+}
@@ -0,0 +1,37 @@
+package node
+
+import (
+	"P2P_BitTorrent/pb"
+	"context"
+	"log"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/peer"
+)
+
+// Intervalo entre heartbeats enviados al tracker.
+const HeartbeatInterval = 10 * time.Second
+
+// StartHeartbeat reporta periódicamente al tracker la capacidad y el espacio libre del nodo
+// y, si no se configuró, fija con la respuesta la clave con la que el tracker firma los
+// permisos de acceso cuando la conexión está autenticada con TLS
+func (s *nodeServer) StartHeartbeat(client pb.TrackerServiceClient, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for ; ; <-ticker.C {
+		capacity, free := s.Capacity()
+		var tracker peer.Peer
+		res, err := client.Heartbeat(context.Background(), &pb.HeartbeatRequest{
+			NodeId:        s.nodeID,
+			CapacityBytes: capacity,
+			FreeBytes:     free,
+		}, grpc.Peer(&tracker))
+		if err != nil {
+			log.Printf("Error al enviar heartbeat al tracker: %v", err)
+			continue
+		}
+		s.pinTrackerKey(res.TrackerKey, &tracker)
+	}
+}
@@ -0,0 +1,43 @@
+package node
+
+import (
+	"P2P_BitTorrent/pb"
+	"context"
+	"log"
+	"time"
+)
+
+// Intervalo entre reportes del inventario de chunks al tracker.
+const InventoryInterval = time.Minute
+
+// StartInventoryReport reporta periódicamente al tracker los chunks almacenados y borra los
+// huérfanos que el tracker indique
+func (s *nodeServer) StartInventoryReport(client pb.TrackerServiceClient, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for range ticker.C {
+		res, err := client.ReportInventory(context.Background(), &pb.InventoryRequest{
+			NodeId:   s.nodeID,
+			ChunkIds: s.inventory(),
+		})
+		if err != nil {
+			log.Printf("Error al reportar el inventario al tracker: %v", err)
+			continue
+		}
+
+		s.mu.Lock()
+		for _, chunkID := range res.DeleteChunkIds {
+			s.removeChunk(chunkID)
+		}
+		s.mu.Unlock()
+	}
+}
+
+// inventory devuelve los IDs de los chunks almacenados en el nodo.
+func (s *nodeServer) inventory() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.store.ids()
+}
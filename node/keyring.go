@@ -0,0 +1,87 @@
+package node
+
+import (
+	"bufio"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+
+	"P2P_BitTorrent/pb"
+	"P2P_BitTorrent/security"
+)
+
+// Keyring guarda las claves de los archivos cifrados que el nodo subió o pudo descifrar,
+// una por línea con el formato "file_id clave_hex".
+type Keyring struct {
+	mu   sync.Mutex
+	path string
+	keys map[string][]byte
+}
+
+// LoadKeyring carga el llavero del nodo desde path; si el archivo no existe, empieza vacío.
+func LoadKeyring(path string) (*Keyring, error) {
+	keyring := &Keyring{path: path, keys: make(map[string][]byte)}
+
+	file, err := os.Open(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return keyring, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("no se pudo leer el llavero: %v", err)
+	}
+	defer file.Close()
+
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) != 2 {
+			continue
+		}
+		key, err := hex.DecodeString(fields[1])
+		if err != nil || len(key) != FileKeySize {
+			return nil, fmt.Errorf("clave inválida en el llavero para el archivo %s", fields[0])
+		}
+		keyring.keys[fields[0]] = key
+	}
+	return keyring, scanner.Err()
+}
+
+// Add guarda la clave de un archivo en el llavero.
+func (k *Keyring) Add(fileID string, key []byte) error {
+	k.mu.Lock()
+	defer k.mu.Unlock()
+
+	if _, exists := k.keys[fileID]; exists {
+		return nil
+	}
+	file, err := os.OpenFile(k.path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0600)
+	if err != nil {
+		return fmt.Errorf("no se pudo guardar la clave en el llavero: %v", err)
+	}
+	defer file.Close()
+	if _, err := fmt.Fprintf(file, "%s %x\n", fileID, key); err != nil {
+		return fmt.Errorf("no se pudo guardar la clave en el llavero: %v", err)
+	}
+	k.keys[fileID] = key
+	return nil
+}
+
+// FileKey busca la clave de un archivo cifrado: primero en el llavero y, si no está, entre
+// las claves envueltas de su manifiesto. Las claves desenvueltas se guardan en el llavero.
+func (k *Keyring) FileKey(identity *security.Identity, manifest *pb.Manifest) ([]byte, error) {
+	k.mu.Lock()
+	key, exists := k.keys[manifest.FileId]
+	k.mu.Unlock()
+	if exists {
+		return key, nil
+	}
+
+	key, err := identity.UnwrapKey(manifest.WrappedKeys)
+	if err != nil {
+		return nil, err
+	}
+	return key, k.Add(manifest.FileId, key)
+}
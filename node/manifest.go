@@ -0,0 +1,136 @@
+package node
+
+import (
+	"P2P_BitTorrent/pb"
+	"P2P_BitTorrent/security"
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+)
+
+// Tamaño nominal de los chunks que se declara en los manifiestos.
+const ManifestChunkSize = 1 << 20
+
+// BuildManifest arma y firma el manifiesto de un archivo recién subido a partir de sus
+// chunks, con los hashes de los chunks de datos en orden, su raíz de Merkle y el resumen de
+// cada chunk guardado, incluidos los de paridad, para los desafíos de almacenamiento. Por
+// eso en erasure coding se llama con la paridad ya calculada. Si el archivo está cifrado,
+// los hashes son los de los chunks cifrados y wrappedKeys lleva su clave para los
+// destinatarios autorizados. Si el archivo es una colección, entries lleva la ruta y la
+// posición de cada uno de sus archivos.
+func BuildManifest(identity *security.Identity, res *pb.JoinResponse, fileName string, chunks []*pb.StoreChunkRequest, encrypted bool, wrappedKeys []*pb.WrappedKey, entries []*pb.CollectionEntry) (*pb.Manifest, error) {
+	manifest := &pb.Manifest{
+		FileId:      res.FileId,
+		FileName:    fileName,
+		ChunkSize:   ManifestChunkSize,
+		Encrypted:   encrypted,
+		WrappedKeys: wrappedKeys,
+		Entries:     entries,
+	}
+	ordered, err := dataChunks(res.FileId, chunks, dataChunkCount(res))
+	if err != nil {
+		return nil, err
+	}
+	stored, err := dataChunks(res.FileId, chunks, len(chunks))
+	if err != nil {
+		return nil, err
+	}
+	for _, chunk := range stored {
+		manifest.StoredChunks = append(manifest.StoredChunks, security.DigestChunk(chunk.ChunkData))
+	}
+
+	data := make([][]byte, len(ordered))
+	for i, chunk := range ordered {
+		sum := sha256.Sum256(chunk.ChunkData)
+		manifest.ChunkHashes = append(manifest.ChunkHashes, sum[:])
+		manifest.FileSize += int64(len(chunk.ChunkData))
+		data[i] = chunk.ChunkData
+	}
+	manifest.MerkleRoot, _ = BuildMerkleTree(data)
+
+	if err := identity.SignManifest(manifest); err != nil {
+		return nil, err
+	}
+	return manifest, nil
+}
+
+// dataChunkCount devuelve la cantidad de chunks de datos asignados por el tracker, sin contar
+// los de paridad.
+func dataChunkCount(res *pb.JoinResponse) int {
+	count := 0
+	for _, info := range res.ChunkMap {
+		if res.StorageMode != StorageErasure || info.Shard < res.DataShards {
+			count++
+		}
+	}
+	return count
+}
+
+// PublisherFingerprint devuelve la clave pública de quien publicó el manifiesto en hexadecimal.
+func PublisherFingerprint(manifest *pb.Manifest) string {
+	return hex.EncodeToString(manifest.PublisherKey)
+}
+
+// checkManifest comprueba la firma del manifiesto de la respuesta del tracker y que
+// corresponda al archivo pedido.
+func checkManifest(res *pb.JoinResponse) error {
+	if err := security.VerifyManifest(res.Manifest); err != nil {
+		return err
+	}
+	if res.Manifest.FileId != res.FileId {
+		return fmt.Errorf("el manifiesto es del archivo %s, no del %s", res.Manifest.FileId, res.FileId)
+	}
+	return security.CheckCollection(res.Manifest)
+}
+
+// expectedHash devuelve el hash que el manifiesto declara para un chunk de datos, o nil si
+// no hay manifiesto o el chunk no es de datos.
+func expectedHash(res *pb.JoinResponse, index int32) []byte {
+	if res.Manifest == nil || index < 1 || int(index) > len(res.Manifest.ChunkHashes) {
+		return nil
+	}
+	return res.Manifest.ChunkHashes[index-1]
+}
+
+// storedChunkCheck devuelve la verificación de un chunk guardado (de datos o de paridad)
+// contra el resumen que el manifiesto publica para él.
+func storedChunkCheck(manifest *pb.Manifest, index int32) chunkCheck {
+	return func(chunk *pb.ChunkResponse) error {
+		if index < 1 || int(index) > len(manifest.StoredChunks) {
+			return fmt.Errorf("el manifiesto no resume el chunk %d", index)
+		}
+		digest := manifest.StoredChunks[index-1]
+		got := security.DigestChunk(chunk.ChunkData)
+		if got.Size != digest.Size || !bytes.Equal(got.BlockRoot, digest.BlockRoot) {
+			return errors.New("no coincide con el resumen del manifiesto")
+		}
+		return nil
+	}
+}
+
+// verifyManifest comprueba que los chunks descargados sean exactamente los del manifiesto.
+func verifyManifest(res *pb.JoinResponse, chunks map[int32][]byte) error {
+	manifest := res.Manifest
+	if len(chunks) != len(manifest.ChunkHashes) {
+		return fmt.Errorf("el manifiesto declara %d chunks y se descargaron %d", len(manifest.ChunkHashes), len(chunks))
+	}
+
+	var size int64
+	for i, expected := range manifest.ChunkHashes {
+		data, exists := chunks[int32(i+1)]
+		if !exists {
+			return fmt.Errorf("falta el chunk %d del archivo", i+1)
+		}
+		sum := sha256.Sum256(data)
+		if !bytes.Equal(sum[:], expected) {
+			return fmt.Errorf("el chunk %d no coincide con el manifiesto", i+1)
+		}
+		size += int64(len(data))
+	}
+	if size != manifest.FileSize {
+		return fmt.Errorf("el archivo mide %d bytes y el manifiesto declara %d", size, manifest.FileSize)
+	}
+	return nil
+}
@@ -0,0 +1,81 @@
+package node
+
+import (
+	"crypto/sha256"
+	"testing"
+
+	"P2P_BitTorrent/pb"
+	"P2P_BitTorrent/security"
+
+	"google.golang.org/protobuf/proto"
+)
+
+func TestVerifyManifest(t *testing.T) {
+	chunks := map[int32][]byte{1: []byte("primer chunk"), 2: []byte("segundo")}
+	manifest := &pb.Manifest{FileId: "archivo", FileSize: int64(len(chunks[1]) + len(chunks[2]))}
+	for i := int32(1); i <= 2; i++ {
+		sum := sha256.Sum256(chunks[i])
+		manifest.ChunkHashes = append(manifest.ChunkHashes, sum[:])
+	}
+
+	tests := []struct {
+		name    string
+		chunks  map[int32][]byte
+		size    int64 // Tamaño declarado (0 = el real).
+		wantErr bool
+	}{
+		{name: "chunks correctos", chunks: chunks},
+		{name: "falta un chunk", chunks: map[int32][]byte{1: chunks[1]}, wantErr: true},
+		{name: "chunk alterado", chunks: map[int32][]byte{1: chunks[1], 2: []byte("segundX")}, wantErr: true},
+		{name: "chunks intercambiados", chunks: map[int32][]byte{1: chunks[2], 2: chunks[1]}, wantErr: true},
+		{name: "chunk de más", chunks: map[int32][]byte{1: chunks[1], 2: chunks[2], 3: nil}, wantErr: true},
+		{name: "tamaño declarado distinto", chunks: chunks, size: 1, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := proto.Clone(manifest).(*pb.Manifest)
+			if tt.size != 0 {
+				m.FileSize = tt.size
+			}
+			err := verifyManifest(&pb.JoinResponse{Manifest: m}, tt.chunks)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("verifyManifest: error %v, se esperaba error: %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestCheckManifest(t *testing.T) {
+	publisher, err := security.NewIdentity()
+	if err != nil {
+		t.Fatalf("NewIdentity: %v", err)
+	}
+	tests := []struct {
+		name    string
+		fileID  string
+		entries []*pb.CollectionEntry
+		tamper  bool
+		wantErr bool
+	}{
+		{name: "manifiesto firmado", fileID: "archivo"},
+		{name: "manifiesto de otro archivo", fileID: "otro", wantErr: true},
+		{name: "firma inválida", fileID: "archivo", tamper: true, wantErr: true},
+		{name: "colección válida", fileID: "archivo", entries: []*pb.CollectionEntry{{Path: "a/b.txt", Size: 1}}},
+		{name: "ruta fuera de la colección", fileID: "archivo", entries: []*pb.CollectionEntry{{Path: "../b.txt", Size: 1}}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			manifest := &pb.Manifest{FileId: "archivo", FileName: "archivo", FileSize: 1, Entries: tt.entries}
+			if err := publisher.SignManifest(manifest); err != nil {
+				t.Fatalf("SignManifest: %v", err)
+			}
+			if tt.tamper {
+				manifest.FileSize++
+			}
+			err := checkManifest(&pb.JoinResponse{FileId: tt.fileID, Manifest: manifest})
+			if (err != nil) != tt.wantErr {
+				t.Errorf("checkManifest: error %v, se esperaba error: %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,220 @@
+package node
+
+import (
+	"P2P_BitTorrent/pb"
+	"bytes"
+	"crypto/sha256"
+	"encoding/binary"
+	"fmt"
+)
+
+// Árbol de Merkle sobre los chunks de datos de un archivo. Cada hoja incluye la posición
+// del chunk, de modo que una prueba válida no sirve para ubicar el chunk en otra posición.
+// Si un nivel tiene una cantidad impar de nodos, el último sube sin cambios.
+
+// merkleLeaf calcula el hash de la hoja de un chunk.
+func merkleLeaf(index int32, data []byte) []byte {
+	h := sha256.New()
+	h.Write([]byte{0})
+	binary.Write(h, binary.BigEndian, index)
+	h.Write(data)
+	return h.Sum(nil)
+}
+
+// merkleNode calcula el hash de un nodo interno a partir de sus hijos.
+func merkleNode(left, right []byte) []byte {
+	h := sha256.New()
+	h.Write([]byte{1})
+	h.Write(left)
+	h.Write(right)
+	return h.Sum(nil)
+}
+
+// BuildMerkleTree devuelve la raíz del árbol de los chunks de datos, en orden desde el
+// índice 1, y la prueba de cada uno.
+func BuildMerkleTree(chunks [][]byte) ([]byte, [][]*pb.MerkleStep) {
+	if len(chunks) == 0 {
+		return nil, nil
+	}
+
+	level := make([][]byte, len(chunks))
+	positions := make([]int, len(chunks)) // Posición de cada chunk en el nivel actual
+	for i, data := range chunks {
+		level[i] = merkleLeaf(int32(i+1), data)
+		positions[i] = i
+	}
+	proofs := make([][]*pb.MerkleStep, len(chunks))
+
+	for len(level) > 1 {
+		for i, pos := range positions {
+			switch {
+			case pos%2 == 1:
+				proofs[i] = append(proofs[i], &pb.MerkleStep{Hash: level[pos-1], Left: true})
+			case pos+1 < len(level):
+				proofs[i] = append(proofs[i], &pb.MerkleStep{Hash: level[pos+1]})
+			}
+			positions[i] = pos / 2
+		}
+
+		var next [][]byte
+		for i := 0; i < len(level); i += 2 {
+			if i+1 < len(level) {
+				next = append(next, merkleNode(level[i], level[i+1]))
+			} else {
+				next = append(next, level[i])
+			}
+		}
+		level = next
+	}
+	return level[0], proofs
+}
+
+// VerifyMerkleProof comprueba que un chunk ocupa la posición index del archivo con la raíz indicada.
+func VerifyMerkleProof(root []byte, index int32, data []byte, proof []*pb.MerkleStep) bool {
+	hash := merkleLeaf(index, data)
+	for _, step := range proof {
+		if step.Left {
+			hash = merkleNode(step.Hash, hash)
+		} else {
+			hash = merkleNode(hash, step.Hash)
+		}
+	}
+	return bytes.Equal(hash, root)
+}
+
+// merkleLevels devuelve la cantidad de nodos de cada nivel de un árbol de total hojas, desde
+// las hojas hasta la raíz.
+func merkleLevels(total int) []int {
+	sizes := []int{total}
+	for size := total; size > 1; {
+		size = (size + 1) / 2
+		sizes = append(sizes, size)
+	}
+	return sizes
+}
+
+// merkleProofLength devuelve los pasos de la prueba de la hoja pos en un árbol con los niveles
+// indicados: uno por cada nivel en que su ancestro tiene hermano.
+func merkleProofLength(sizes []int, pos int) int {
+	steps := 0
+	for t := 0; t < len(sizes)-1; t++ {
+		if pos%2 == 1 || pos+1 < sizes[t] {
+			steps++
+		}
+		pos /= 2
+	}
+	return steps
+}
+
+// DeriveMerkleProof calcula la prueba de Merkle del chunk index de un archivo de total chunks
+// de datos sin conocer todos sus chunks, a partir de los chunks conocidos (leaves, por índice)
+// y de las pruebas válidas de algunos de ellos (proofs). Sirve para dar una prueba a un chunk
+// reconstruido: alcanza con conocer sus vecinos y la prueba de alguno de ellos.
+func DeriveMerkleProof(root []byte, total int, index int32, leaves map[int32][]byte, proofs map[int32][]*pb.MerkleStep) ([]*pb.MerkleStep, error) {
+	if index < 1 || int(index) > total || leaves[index] == nil {
+		return nil, fmt.Errorf("no se conoce el chunk %d", index)
+	}
+	sizes := merkleLevels(total)
+	known := make([]map[int][]byte, len(sizes)) // Hashes conocidos de cada nivel, por posición.
+	for t := range known {
+		known[t] = make(map[int][]byte)
+	}
+	for i, data := range leaves {
+		if i >= 1 && int(i) <= total {
+			known[0][int(i-1)] = merkleLeaf(i, data)
+		}
+	}
+
+	// Cada prueba válida aporta los ancestros de su chunk y sus hermanos
+	for i, proof := range proofs {
+		data, exists := leaves[i]
+		if !exists || len(proof) != merkleProofLength(sizes, int(i-1)) || !VerifyMerkleProof(root, i, data, proof) {
+			continue
+		}
+		hash, pos, step := merkleLeaf(i, data), int(i-1), 0
+		for t := 0; t < len(sizes)-1; t++ {
+			known[t][pos] = hash
+			switch {
+			case pos%2 == 1:
+				known[t][pos-1] = proof[step].Hash
+				hash = merkleNode(proof[step].Hash, hash)
+				step++
+			case pos+1 < sizes[t]:
+				known[t][pos+1] = proof[step].Hash
+				hash = merkleNode(hash, proof[step].Hash)
+				step++
+			}
+			pos /= 2
+		}
+	}
+
+	// Completar los nodos internos cuyos hijos se conocen
+	for t := 0; t < len(sizes)-1; t++ {
+		for pos := 0; pos < sizes[t+1]; pos++ {
+			if known[t+1][pos] != nil {
+				continue
+			}
+			left, right := known[t][2*pos], known[t][2*pos+1]
+			switch {
+			case 2*pos+1 >= sizes[t] && left != nil:
+				known[t+1][pos] = left
+			case left != nil && right != nil:
+				known[t+1][pos] = merkleNode(left, right)
+			}
+		}
+	}
+
+	var proof []*pb.MerkleStep
+	pos := int(index - 1)
+	for t := 0; t < len(sizes)-1; t++ {
+		switch {
+		case pos%2 == 1:
+			if known[t][pos-1] == nil {
+				return nil, fmt.Errorf("falta el nodo %d del nivel %d para la prueba del chunk %d", pos-1, t, index)
+			}
+			proof = append(proof, &pb.MerkleStep{Hash: known[t][pos-1], Left: true})
+		case pos+1 < sizes[t]:
+			if known[t][pos+1] == nil {
+				return nil, fmt.Errorf("falta el nodo %d del nivel %d para la prueba del chunk %d", pos+1, t, index)
+			}
+			proof = append(proof, &pb.MerkleStep{Hash: known[t][pos+1]})
+		}
+		pos /= 2
+	}
+	if !VerifyMerkleProof(root, index, leaves[index], proof) {
+		return nil, fmt.Errorf("la prueba calculada del chunk %d no coincide con la raíz", index)
+	}
+	return proof, nil
+}
+
+// dataChunks devuelve los primeros count chunks de un archivo ordenados por índice; los de
+// datos van primero y los de paridad después.
+func dataChunks(fileID string, chunks []*pb.StoreChunkRequest, count int) ([]*pb.StoreChunkRequest, error) {
+	ordered := make([]*pb.StoreChunkRequest, count)
+	for i := range ordered {
+		ordered[i] = FindChunk(chunks, ChunkID(&pb.ChunkKey{FileId: fileID, Index: int32(i + 1)}))
+		if ordered[i] == nil {
+			return nil, fmt.Errorf("falta el chunk %d del archivo", i+1)
+		}
+	}
+	return ordered, nil
+}
+
+// AttachMerkleProofs calcula el árbol de Merkle de los chunks de datos de un archivo, agrega
+// a cada chunk su prueba para que los nodos la guarden junto a él, y devuelve la raíz.
+func AttachMerkleProofs(fileID string, chunks []*pb.StoreChunkRequest) ([]byte, error) {
+	ordered, err := dataChunks(fileID, chunks, len(chunks))
+	if err != nil {
+		return nil, err
+	}
+
+	data := make([][]byte, len(ordered))
+	for i, chunk := range ordered {
+		data[i] = chunk.ChunkData
+	}
+	root, proofs := BuildMerkleTree(data)
+	for i, chunk := range ordered {
+		chunk.Proof = proofs[i]
+	}
+	return root, nil
+}
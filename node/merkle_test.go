@@ -0,0 +1,193 @@
+package node
+
+import (
+	"fmt"
+	"math/rand"
+	"reflect"
+	"testing"
+
+	"P2P_BitTorrent/pb"
+)
+
+// testChunks devuelve n chunks distintos de contenido pseudoaleatorio.
+func testChunks(rng *rand.Rand, n int) [][]byte {
+	chunks := make([][]byte, n)
+	for i := range chunks {
+		chunks[i] = randomChunk(rng, 10+rng.Intn(50))
+	}
+	return chunks
+}
+
+func TestMerkleProofs(t *testing.T) {
+	rng := rand.New(rand.NewSource(4))
+	for total := 1; total <= 17; total++ {
+		chunks := testChunks(rng, total)
+		root, proofs := BuildMerkleTree(chunks)
+		for i, data := range chunks {
+			index := int32(i + 1)
+			if !VerifyMerkleProof(root, index, data, proofs[i]) {
+				t.Errorf("%d chunks: la prueba del chunk %d no verifica", total, index)
+			}
+			if total > 1 && VerifyMerkleProof(root, index%int32(total)+1, data, proofs[i]) {
+				t.Errorf("%d chunks: la prueba del chunk %d verifica en otra posición", total, index)
+			}
+			tampered := append([]byte{data[0] ^ 1}, data[1:]...)
+			if VerifyMerkleProof(root, index, tampered, proofs[i]) {
+				t.Errorf("%d chunks: la prueba del chunk %d verifica con otro contenido", total, index)
+			}
+		}
+	}
+}
+
+func TestDeriveMerkleProofFromNeighbors(t *testing.T) {
+	rng := rand.New(rand.NewSource(5))
+	for total := 1; total <= 17; total++ {
+		chunks := testChunks(rng, total)
+		root, proofs := BuildMerkleTree(chunks)
+		for i := range chunks {
+			t.Run(fmt.Sprintf("%d-de-%d", i+1, total), func(t *testing.T) {
+				index := int32(i + 1)
+				leaves := map[int32][]byte{index: chunks[i]}
+				known := make(map[int32][]*pb.MerkleStep)
+				// Solo se conocen los vecinos y sus pruebas
+				for _, neighbor := range []int{i - 1, i + 1} {
+					if neighbor >= 0 && neighbor < total {
+						leaves[int32(neighbor+1)] = chunks[neighbor]
+						known[int32(neighbor+1)] = proofs[neighbor]
+					}
+				}
+
+				got, err := DeriveMerkleProof(root, total, index, leaves, known)
+				if err != nil {
+					t.Fatalf("DeriveMerkleProof: %v", err)
+				}
+				if len(got) != len(proofs[i]) || (len(got) > 0 && !reflect.DeepEqual(got, proofs[i])) {
+					t.Errorf("prueba calculada %v, se esperaba %v", got, proofs[i])
+				}
+			})
+		}
+	}
+}
+
+func TestDeriveMerkleProofNeedsNeighbors(t *testing.T) {
+	rng := rand.New(rand.NewSource(6))
+	chunks := testChunks(rng, 8)
+	root, proofs := BuildMerkleTree(chunks)
+
+	tests := []struct {
+		name   string
+		leaves []int32 // Chunks conocidos además del 3.
+		proofs []int32 // Chunks cuya prueba se conoce.
+	}{
+		{name: "sin vecinos"},
+		{name: "vecino sin prueba", leaves: []int32{4}},
+		{name: "prueba de otro chunk lejano", leaves: []int32{8}, proofs: []int32{8}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			leaves := map[int32][]byte{3: chunks[2]}
+			known := make(map[int32][]*pb.MerkleStep)
+			for _, i := range tt.leaves {
+				leaves[i] = chunks[i-1]
+			}
+			for _, i := range tt.proofs {
+				known[i] = proofs[i-1]
+			}
+			if _, err := DeriveMerkleProof(root, len(chunks), 3, leaves, known); err == nil {
+				t.Error("se calculó una prueba sin los datos necesarios")
+			}
+		})
+	}
+
+	// Una prueba falsa de un vecino no sirve para calcular la del chunk
+	forged := map[int32][]*pb.MerkleStep{4: proofs[0]}
+	if _, err := DeriveMerkleProof(root, len(chunks), 3, map[int32][]byte{3: chunks[2], 4: chunks[3]}, forged); err == nil {
+		t.Error("se aceptó la prueba falsa de un vecino")
+	}
+}
+
+func TestVerifyMerkleProofRejectsForgeries(t *testing.T) {
+	rng := rand.New(rand.NewSource(7))
+	chunks := testChunks(rng, 6)
+	root, proofs := BuildMerkleTree(chunks)
+
+	// Cada caso altera una copia de la prueba del chunk 3
+	tests := []struct {
+		name   string
+		forge  func(proof []*pb.MerkleStep) []*pb.MerkleStep
+		index  int32
+		wantOK bool
+	}{
+		{name: "prueba original", forge: func(p []*pb.MerkleStep) []*pb.MerkleStep { return p }, index: 3, wantOK: true},
+		{name: "hash de un hermano alterado", forge: func(p []*pb.MerkleStep) []*pb.MerkleStep {
+			p[1].Hash = append([]byte{p[1].Hash[0] ^ 1}, p[1].Hash[1:]...)
+			return p
+		}, index: 3},
+		{name: "lado de un hermano invertido", forge: func(p []*pb.MerkleStep) []*pb.MerkleStep {
+			p[0].Left = !p[0].Left
+			return p
+		}, index: 3},
+		{name: "prueba recortada", forge: func(p []*pb.MerkleStep) []*pb.MerkleStep { return p[:len(p)-1] }, index: 3},
+		{name: "paso de más", forge: func(p []*pb.MerkleStep) []*pb.MerkleStep { return append(p, &pb.MerkleStep{Hash: root}) }, index: 3},
+		{name: "prueba vacía", forge: func([]*pb.MerkleStep) []*pb.MerkleStep { return nil }, index: 3},
+		{name: "otra posición", forge: func(p []*pb.MerkleStep) []*pb.MerkleStep { return p }, index: 4},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			proof := make([]*pb.MerkleStep, len(proofs[2]))
+			for i, step := range proofs[2] {
+				proof[i] = &pb.MerkleStep{Hash: append([]byte(nil), step.Hash...), Left: step.Left}
+			}
+			if got := VerifyMerkleProof(root, tt.index, chunks[2], tt.forge(proof)); got != tt.wantOK {
+				t.Errorf("VerifyMerkleProof = %v, se esperaba %v", got, tt.wantOK)
+			}
+		})
+	}
+}
+
+func TestAttachMerkleProofs(t *testing.T) {
+	rng := rand.New(rand.NewSource(8))
+	chunks := testChunks(rng, 5)
+
+	tests := []struct {
+		name    string
+		indexes []int32 // Chunks que se suben, en este orden.
+		wantErr bool
+	}{
+		{name: "en orden", indexes: []int32{1, 2, 3, 4, 5}},
+		{name: "desordenados", indexes: []int32{4, 1, 5, 3, 2}},
+		{name: "falta un chunk", indexes: []int32{1, 2, 4, 5}, wantErr: true},
+		{name: "chunk de otro archivo", indexes: []int32{1, 2, 3, 4, 0}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var upload []*pb.StoreChunkRequest
+			for _, index := range tt.indexes {
+				key := &pb.ChunkKey{FileId: "archivo", Index: index}
+				data := []byte("ajeno")
+				if index == 0 {
+					key = &pb.ChunkKey{FileId: "otro", Index: 5}
+				} else {
+					data = chunks[index-1]
+				}
+				upload = append(upload, &pb.StoreChunkRequest{ChunkId: ChunkID(key), ChunkData: data})
+			}
+
+			root, err := AttachMerkleProofs("archivo", upload)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("AttachMerkleProofs: error %v, se esperaba error: %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			if want, _ := BuildMerkleTree(chunks); !reflect.DeepEqual(root, want) {
+				t.Errorf("raíz %x, se esperaba %x", root, want)
+			}
+			for i, chunk := range upload {
+				if !VerifyMerkleProof(root, tt.indexes[i], chunk.ChunkData, chunk.Proof) {
+					t.Errorf("la prueba adjunta al chunk %d no verifica", tt.indexes[i])
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,194 @@
+package node
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Direcciones de tráfico que se pueden limitar.
+const (
+	DirectionUpload   = "up"
+	DirectionDownload = "down"
+)
+
+// AllPeers identifica el límite por defecto aplicado a cada peer sin límite propio.
+const AllPeers = "*"
+
+// tokenBucket limita la tasa de bytes por segundo. Una tasa de 0 significa sin límite.
+type tokenBucket struct {
+	mu     sync.Mutex
+	rate   float64   // Bytes por segundo.
+	tokens float64   // Bytes disponibles; puede ser negativo mientras se paga una deuda.
+	last   time.Time // Último momento en que se recargaron los tokens.
+}
+
+// newTokenBucket crea un bucket con capacidad para un segundo de tráfico.
+func newTokenBucket(rate int64) *tokenBucket {
+	return &tokenBucket{rate: float64(rate), tokens: float64(rate), last: time.Now()}
+}
+
+// setRate cambia la tasa del bucket sin perder la deuda acumulada.
+func (b *tokenBucket) setRate(rate int64) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.refill()
+	b.rate = float64(rate)
+	if b.tokens > b.rate {
+		b.tokens = b.rate
+	}
+}
+
+// refill recarga los tokens según el tiempo transcurrido. Debe llamarse con mu tomado.
+func (b *tokenBucket) refill() {
+	now := time.Now()
+	b.tokens += now.Sub(b.last).Seconds() * b.rate
+	if b.tokens > b.rate {
+		b.tokens = b.rate
+	}
+	b.last = now
+}
+
+// wait consume n bytes del bucket y bloquea hasta que la tasa lo permita.
+func (b *tokenBucket) wait(n int) {
+	b.mu.Lock()
+	if b.rate <= 0 {
+		b.mu.Unlock()
+		return
+	}
+	b.refill()
+	b.tokens -= float64(n)
+	delay := time.Duration(0)
+	if b.tokens < 0 {
+		delay = time.Duration(-b.tokens / b.rate * float64(time.Second))
+	}
+	b.mu.Unlock()
+
+	time.Sleep(delay)
+}
+
+// refund devuelve n bytes consumidos de más, sin superar la capacidad del bucket.
+func (b *tokenBucket) refund(n int) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.rate <= 0 {
+		return
+	}
+	b.refill()
+	b.tokens += float64(n)
+	if b.tokens > b.rate {
+		b.tokens = b.rate
+	}
+}
+
+// bandwidthLimiter combina un límite global con límites por peer para una dirección de tráfico.
+type bandwidthLimiter struct {
+	mu        sync.Mutex
+	global    *tokenBucket
+	peerRate  int64                   // Límite por defecto de cada peer.
+	overrides map[string]int64        // Límites específicos por peer.
+	peers     map[string]*tokenBucket // Buckets por peer, creados bajo demanda.
+}
+
+// newBandwidthLimiter crea un limitador con los límites global y por peer indicados.
+func newBandwidthLimiter(globalRate, peerRate int64) *bandwidthLimiter {
+	return &bandwidthLimiter{
+		global:    newTokenBucket(globalRate),
+		peerRate:  peerRate,
+		overrides: make(map[string]int64),
+		peers:     make(map[string]*tokenBucket),
+	}
+}
+
+// bucket obtiene el bucket de un peer, creándolo con su límite si no existe.
+func (l *bandwidthLimiter) bucket(peerID string) *tokenBucket {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	b, exists := l.peers[peerID]
+	if !exists {
+		rate, ok := l.overrides[peerID]
+		if !ok {
+			rate = l.peerRate
+		}
+		b = newTokenBucket(rate)
+		l.peers[peerID] = b
+	}
+	return b
+}
+
+// wait aplica primero el límite del peer y luego el global.
+func (l *bandwidthLimiter) wait(peerID string, n int) {
+	l.bucket(peerID).wait(n)
+	l.global.wait(n)
+}
+
+// settle ajusta una reserva hecha con wait antes de conocer el tamaño real del tráfico: con n
+// positivo consume los bytes que faltaron y espera, y con n negativo devuelve los que sobraron.
+func (l *bandwidthLimiter) settle(peerID string, n int) {
+	if n >= 0 {
+		l.wait(peerID, n)
+		return
+	}
+	l.bucket(peerID).refund(-n)
+	l.global.refund(-n)
+}
+
+// setGlobal cambia el límite global.
+func (l *bandwidthLimiter) setGlobal(rate int64) {
+	l.global.setRate(rate)
+}
+
+// setPeer cambia el límite de un peer, o el límite por defecto si peerID es AllPeers.
+func (l *bandwidthLimiter) setPeer(peerID string, rate int64) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if peerID == AllPeers {
+		l.peerRate = rate
+		for id, b := range l.peers {
+			if _, ok := l.overrides[id]; !ok {
+				b.setRate(rate)
+			}
+		}
+		return
+	}
+
+	l.overrides[peerID] = rate
+	if b, exists := l.peers[peerID]; exists {
+		b.setRate(rate)
+	}
+}
+
+// describe devuelve un resumen legible de los límites configurados.
+func (l *bandwidthLimiter) describe() string {
+	l.global.mu.Lock()
+	global := int64(l.global.rate)
+	l.global.mu.Unlock()
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	parts := []string{fmt.Sprintf("global=%s", formatRate(global)), fmt.Sprintf("por peer=%s", formatRate(l.peerRate))}
+	var peers []string
+	for peerID := range l.overrides {
+		peers = append(peers, peerID)
+	}
+	sort.Strings(peers)
+	for _, peerID := range peers {
+		parts = append(parts, fmt.Sprintf("%s=%s", peerID, formatRate(l.overrides[peerID])))
+	}
+	return strings.Join(parts, ", ")
+}
+
+// formatRate muestra una tasa en KB/s.
+func formatRate(rate int64) string {
+	if rate <= 0 {
+		return "sin límite"
+	}
+	return fmt.Sprintf("%d KB/s", rate/1024)
+}
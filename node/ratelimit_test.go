@@ -0,0 +1,96 @@
+package node
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+func TestTokenBucketWait(t *testing.T) {
+	tests := []struct {
+		name      string
+		rate      int64
+		sizes     []int // Bytes consumidos uno tras otro.
+		wantDelay time.Duration
+	}{
+		{name: "sin límite", rate: 0, sizes: []int{1 << 30}, wantDelay: 0},
+		{name: "dentro de la ráfaga", rate: 1 << 20, sizes: []int{1000, 1000}, wantDelay: 0},
+		{name: "excede la ráfaga", rate: 100000, sizes: []int{100000, 10000}, wantDelay: 100 * time.Millisecond},
+		{name: "una solicitud mayor que la ráfaga", rate: 100000, sizes: []int{120000}, wantDelay: 200 * time.Millisecond},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b := newTokenBucket(tt.rate)
+			start := time.Now()
+			for _, n := range tt.sizes {
+				b.wait(n)
+			}
+			elapsed := time.Since(start)
+			if elapsed < tt.wantDelay-10*time.Millisecond || elapsed > tt.wantDelay+150*time.Millisecond {
+				t.Errorf("la espera duró %v, se esperaba alrededor de %v", elapsed, tt.wantDelay)
+			}
+		})
+	}
+}
+
+func TestTokenBucketAccounting(t *testing.T) {
+	const rate = 1000
+	tests := []struct {
+		name       string
+		apply      func(b *tokenBucket)
+		wantTokens float64
+	}{
+		{name: "la devolución no supera la capacidad", apply: func(b *tokenBucket) { b.refund(500) }, wantTokens: rate},
+		{name: "la devolución paga la deuda", apply: func(b *tokenBucket) { b.tokens = -400; b.refund(300) }, wantTokens: -100},
+		{name: "bajar la tasa recorta los tokens", apply: func(b *tokenBucket) { b.setRate(200) }, wantTokens: 200},
+		{name: "cambiar la tasa conserva la deuda", apply: func(b *tokenBucket) { b.tokens = -400; b.setRate(2000) }, wantTokens: -400},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b := newTokenBucket(rate)
+			tt.apply(b)
+			// La recarga por el tiempo transcurrido durante el test es despreciable
+			if math.Abs(b.tokens-tt.wantTokens) > 10 {
+				t.Errorf("tokens = %.0f, se esperaba %.0f", b.tokens, tt.wantTokens)
+			}
+		})
+	}
+}
+
+func TestBandwidthLimiterPeerRates(t *testing.T) {
+	l := newBandwidthLimiter(0, 1<<10)
+	l.bucket("a")
+	l.bucket("b")
+	l.setPeer("a", 5<<10)
+	l.setPeer(AllPeers, 2<<10)
+	l.bucket("c")
+
+	tests := []struct {
+		peer string
+		want float64
+	}{
+		{peer: "a", want: 5 << 10}, // Su límite propio no cambia con el límite por defecto.
+		{peer: "b", want: 2 << 10}, // Se actualiza el bucket ya creado.
+		{peer: "c", want: 2 << 10}, // Se crea con el nuevo límite por defecto.
+	}
+	for _, tt := range tests {
+		if got := l.bucket(tt.peer).rate; got != tt.want {
+			t.Errorf("límite del peer %s = %.0f, se esperaba %.0f", tt.peer, got, tt.want)
+		}
+	}
+	if got, want := l.describe(), "global=sin límite, por peer=2 KB/s, a=5 KB/s"; got != want {
+		t.Errorf("describe() = %q, se esperaba %q", got, want)
+	}
+}
+
+func TestBandwidthLimiterSettle(t *testing.T) {
+	l := newBandwidthLimiter(1000, 1000)
+	l.wait("a", 800)
+	l.settle("a", -500) // Se reservaron 800 bytes y se usaron 300.
+
+	for name, b := range map[string]*tokenBucket{"global": l.global, "peer": l.bucket("a")} {
+		if math.Abs(b.tokens-700) > 10 {
+			t.Errorf("tokens del bucket %s = %.0f, se esperaba 700", name, b.tokens)
+		}
+	}
+}
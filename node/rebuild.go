@@ -0,0 +1,144 @@
+package node
+
+import (
+	"P2P_BitTorrent/pb"
+	"P2P_BitTorrent/security"
+	"context"
+	"fmt"
+	"log"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// readChunk obtiene un chunk con su prueba de Merkle: de la copia local si el nodo lo tiene y
+// pasa la verificación, o si no de alguno de los nodos indicados.
+func (s *nodeServer) readChunk(nodeAddresses []string, chunkID string, token *pb.AccessToken, check chunkCheck) ([]byte, []*pb.MerkleStep, error) {
+	s.mu.Lock()
+	data, exists, err := s.store.get(chunkID)
+	proof := s.proofs[chunkID]
+	s.mu.Unlock()
+
+	if err == nil && exists {
+		if err := check(&pb.ChunkResponse{ChunkData: data, Proof: proof}); err == nil {
+			return data, proof, nil
+		}
+		log.Printf("La copia local del chunk %s no coincide con el manifiesto; se pide a otro nodo", chunkID)
+	}
+	res, err := s.fetchResponse(nodeAddresses, chunkID, token, check)
+	if err != nil {
+		return nil, nil, err
+	}
+	return res.ChunkData, res.Proof, nil
+}
+
+// RebuildShards reconstruye los shards perdidos de una franja a partir de los que quedan y
+// los guarda en los nodos que indicó el tracker. Cada shard, leído o reconstruido, se
+// verifica contra su resumen en el manifiesto firmado; los shards de datos reconstruidos
+// reciben su prueba de Merkle, calculada a partir de sus vecinos.
+func (s *nodeServer) RebuildShards(ctx context.Context, req *pb.RebuildShardsRequest) (*pb.RebuildShardsResponse, error) {
+	k, m := int(req.DataShards), int(req.ParityShards)
+	if k <= 0 || m < 0 || k+m > 255 {
+		return nil, status.Errorf(codes.InvalidArgument, "tamaño de franja inválido: %d+%d", k, m)
+	}
+	manifest := req.Manifest
+	if err := security.VerifyManifest(manifest); err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "manifiesto rechazado: %v", err)
+	}
+	if manifest.FileId != req.FileId {
+		return nil, status.Errorf(codes.InvalidArgument, "el manifiesto es del archivo %s, no del %s", manifest.FileId, req.FileId)
+	}
+	for _, info := range append(append([]*pb.ChunkInfo(nil), req.Shards...), req.Neighbors...) {
+		if info.Key.GetFileId() != req.FileId {
+			return nil, status.Errorf(codes.InvalidArgument, "el chunk %s no es del archivo %s", ChunkID(info.Key), req.FileId)
+		}
+	}
+
+	// Leer los shards que quedan
+	shards := make([][]byte, k+m)
+	known := make([]bool, k)
+	leaves := make(map[int32][]byte)
+	proofs := make(map[int32][]*pb.MerkleStep)
+	var missing []*pb.ChunkInfo
+	for _, info := range req.Shards {
+		shard, index, chunkID := int(info.Shard), info.Key.Index, ChunkID(info.Key)
+		if shard < 0 || shard >= k+m {
+			return nil, status.Errorf(codes.InvalidArgument, "posición de shard inválida: %d", shard)
+		}
+		if shard < k {
+			known[shard] = true
+		}
+		if len(info.Nodes) == 0 {
+			if req.Targets[chunkID] == "" {
+				return nil, status.Errorf(codes.InvalidArgument, "no se indicó dónde guardar el shard %s", chunkID)
+			}
+			missing = append(missing, info)
+			continue
+		}
+		data, proof, err := s.readChunk(info.Nodes, chunkID, req.ReadToken, storedChunkCheck(manifest, index))
+		if err != nil {
+			log.Printf("No se pudo leer el shard %s para reconstruir la franja: %v", chunkID, err)
+			continue
+		}
+		shards[shard] = data
+		if shard < k {
+			leaves[index], proofs[index] = data, proof
+		}
+	}
+
+	data, err := ReconstructStripe(shards, k, known)
+	if err != nil {
+		return nil, status.Errorf(codes.FailedPrecondition, "no se pudo reconstruir la franja: %v", err)
+	}
+	parity, err := EncodeStripe(data, m)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "no se pudo calcular la paridad: %v", err)
+	}
+
+	// Los chunks de datos de la franja y sus vecinos permiten calcular las pruebas de Merkle
+	for _, info := range req.Shards {
+		if int(info.Shard) < k {
+			leaves[info.Key.Index] = data[info.Shard]
+		}
+	}
+	for _, info := range req.Neighbors {
+		chunkID := ChunkID(info.Key)
+		neighbor, proof, err := s.readChunk(info.Nodes, chunkID, req.ReadToken, storedChunkCheck(manifest, info.Key.Index))
+		if err != nil {
+			log.Printf("No se pudo leer el chunk vecino %s: %v", chunkID, err)
+			continue
+		}
+		leaves[info.Key.Index], proofs[info.Key.Index] = neighbor, proof
+	}
+
+	stored := make(map[string]string)
+	for _, info := range missing {
+		shard, index, chunkID := int(info.Shard), info.Key.Index, ChunkID(info.Key)
+		chunk := &pb.StoreChunkRequest{ChunkId: chunkID, NodeId: s.nodeID, Token: req.Token}
+		if shard < k {
+			chunk.ChunkData = data[shard]
+			if chunk.Proof, err = DeriveMerkleProof(manifest.MerkleRoot, len(manifest.ChunkHashes), index, leaves, proofs); err != nil {
+				log.Printf("No se pudo calcular la prueba de Merkle del shard %s: %v", chunkID, err)
+				continue
+			}
+		} else {
+			chunk.ChunkData = parity[shard-k]
+		}
+		if err := storedChunkCheck(manifest, index)(&pb.ChunkResponse{ChunkData: chunk.ChunkData}); err != nil {
+			log.Printf("El shard %s reconstruido %v; no se guarda", chunkID, err)
+			continue
+		}
+
+		target := req.Targets[chunkID]
+		if err := s.SendChunkToNode(target, chunk); err != nil {
+			continue
+		}
+		stored[chunkID] = target
+	}
+
+	log.Printf("Franja del archivo %s reparada: %d de %d shards reconstruidos", req.FileId, len(stored), len(missing))
+	return &pb.RebuildShardsResponse{
+		Message: fmt.Sprintf("%d de %d shards reconstruidos", len(stored), len(missing)),
+		Stored:  stored,
+	}, nil
+}
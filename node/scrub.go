@@ -0,0 +1,78 @@
+package node
+
+import (
+	"P2P_BitTorrent/pb"
+	"context"
+	"crypto/sha256"
+	"log"
+	"time"
+)
+
+// Pausa entre dos pasadas completas del scrubber.
+const ScrubPause = time.Minute
+
+// StartScrubber relee periódicamente los chunks almacenados, a la tasa configurada, y
+// aparta en cuarentena los que ya no coinciden con el hash calculado al guardarlos. Los
+// chunks corruptos se reportan al tracker para que los vuelva a copiar desde otra réplica.
+func (s *nodeServer) StartScrubber(client pb.TrackerServiceClient, pause time.Duration) {
+	for {
+		if corrupt := s.scrubPass(); len(corrupt) > 0 {
+			s.reportCorrupt(client, corrupt)
+		}
+		time.Sleep(pause)
+	}
+}
+
+// scrubPass verifica todos los chunks almacenados y devuelve los que se pusieron en cuarentena.
+func (s *nodeServer) scrubPass() []string {
+	var corrupt []string
+	for _, chunkID := range s.inventory() {
+		s.mu.Lock()
+		data, exists, err := s.store.get(chunkID)
+		expected, hashed := s.hashes[chunkID]
+		s.mu.Unlock()
+		if !exists || !hashed {
+			continue // Borrado durante la pasada
+		}
+
+		// Un chunk cifrado en reposo que no pasa la autenticación también está corrupto
+		s.scrub.wait(len(data))
+		if err == nil && sha256.Sum256(data) == expected {
+			continue
+		}
+		if s.quarantineChunk(chunkID, expected) {
+			corrupt = append(corrupt, chunkID)
+		}
+	}
+	return corrupt
+}
+
+// quarantineChunk aparta un chunk corrupto para que no se sirva ni se reporte como
+// almacenado. expected es el hash con el que se verificó, para no apartar un chunk
+// que se volvió a guardar durante la verificación.
+func (s *nodeServer) quarantineChunk(chunkID string, expected [sha256.Size]byte) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if current, exists := s.hashes[chunkID]; !exists || current != expected {
+		return false
+	}
+
+	s.quarantine[chunkID] = s.store.take(chunkID)
+	delete(s.hashes, chunkID)
+	log.Printf("Chunk %s en cuarentena: su hash no coincide con el calculado al guardarlo", chunkID)
+	return true
+}
+
+// reportCorrupt avisa al tracker de los chunks puestos en cuarentena.
+func (s *nodeServer) reportCorrupt(client pb.TrackerServiceClient, chunkIDs []string) {
+	res, err := client.ReportCorruptChunks(context.Background(), &pb.CorruptChunksRequest{
+		NodeId:   s.nodeID,
+		ChunkIds: chunkIDs,
+	})
+	if err != nil {
+		log.Printf("Error al reportar chunks corruptos al tracker: %v", err)
+		return
+	}
+	log.Println(res.Message)
+}
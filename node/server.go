@@ -2,34 +2,81 @@ package node
 
 import (
 	"P2P_BitTorrent/pb"
+	"P2P_BitTorrent/security"
 	"context"
+	"crypto/ed25519"
+	"crypto/sha256"
 	"fmt"
 	"log"
 	"net"
 	"sync"
 
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
+// Config agrupa los parámetros configurables del nodo.
+type Config struct {
+	UnchokeSlots      int                   // Cantidad de peers a los que se sirven chunks simultáneamente.
+	UploadLimit       int64                 // Límite global de subida en bytes por segundo (0 = sin límite).
+	DownloadLimit     int64                 // Límite global de descarga en bytes por segundo (0 = sin límite).
+	PeerUploadLimit   int64                 // Límite de subida hacia cada peer en bytes por segundo (0 = sin límite).
+	PeerDownloadLimit int64                 // Límite de descarga desde cada peer en bytes por segundo (0 = sin límite).
+	QuotaBytes        int64                 // Espacio máximo para almacenar chunks (0 = sin límite).
+	Labels            map[string]string     // Dominios de falla del nodo: "host", "rack" y "zone".
+	ScrubRate         int64                 // Bytes por segundo que relee el scrubber (0 = sin límite).
+	DataDir           string                // Carpeta donde se guardan los chunks ("" = en memoria).
+	StoreKeys         *StoreKeys            // Claves para cifrar los chunks en reposo (nil = sin cifrar).
+	TrackerKey        ed25519.PublicKey     // Clave del tracker (nil = la que envíe por TLS).
+	Credentials       *security.Credentials // Credenciales de TLS (nil = sin cifrar).
+}
+
 // Estructura del nodo para manejar tanto el servidor como el cliente gRPC
 type nodeServer struct {
 	pb.UnimplementedNodeServiceServer
-	mu     sync.Mutex
-	chunks map[string][]byte // Mapa para almacenar los chunks del nodo
+	mu               sync.Mutex
+	nodeID           string                       // Dirección ip:puerto con la que el nodo se identifica
+	store            *chunkStore                  // Chunks almacenados en el nodo
+	hashes           map[string][sha256.Size]byte // Hash de cada chunk calculado al guardarlo
+	quarantine       map[string][]byte            // Chunks corruptos apartados por el scrubber
+	proofs           map[string][]*pb.MerkleStep  // Prueba de Merkle recibida con cada chunk de datos
+	quota            int64                        // Espacio máximo para chunks (0 = sin límite)
+	used             int64                        // Bytes ocupados por los chunks almacenados
+	choker           *chokeManager                // Decide a qué peers se les sirven chunks
+	upload           *bandwidthLimiter            // Limita los bytes servidos a otros nodos
+	download         *bandwidthLimiter            // Limita los bytes descargados de otros nodos
+	labels           map[string]string            // Dominios de falla que se reportan al tracker
+	scrub            *tokenBucket                 // Limita los bytes que relee el scrubber
+	credentials      *security.Credentials        // Credenciales de TLS para el servidor y las conexiones salientes
+	trackerKey       ed25519.PublicKey            // Clave con la que el tracker firma los permisos de acceso
+	trackerKeyWarned bool                         // Ya se avisó que la clave del tracker no se puede fijar sin TLS
 }
 
 // Inicializar el servidor con un mapa de chunks vacío
-func newNodeServer() *nodeServer {
+func NewNodeServer(nodeID string, cfg Config) *nodeServer {
 	return &nodeServer{
-		chunks: make(map[string][]byte),
+		nodeID:      nodeID,
+		store:       newChunkStore(cfg.DataDir, cfg.StoreKeys),
+		hashes:      make(map[string][sha256.Size]byte),
+		quarantine:  make(map[string][]byte),
+		proofs:      make(map[string][]*pb.MerkleStep),
+		quota:       cfg.QuotaBytes,
+		choker:      newChokeManager(cfg.UnchokeSlots),
+		upload:      newBandwidthLimiter(cfg.UploadLimit, cfg.PeerUploadLimit),
+		download:    newBandwidthLimiter(cfg.DownloadLimit, cfg.PeerDownloadLimit),
+		labels:      cfg.Labels,
+		scrub:       newTokenBucket(cfg.ScrubRate),
+		credentials: cfg.Credentials,
+		trackerKey:  cfg.TrackerKey,
 	}
 }
 
 // startNodeServer inicia el servidor gRPC del nodo
-func StartNodeServer(nodeID string) {
+func StartNodeServer(node *nodeServer) {
 
 	// Separar la IP del puerto
-	_, port, err := net.SplitHostPort(nodeID)
+	_, port, err := net.SplitHostPort(node.nodeID)
 	if err != nil {
 		log.Fatalf("Error al separar la dirección IP y el puerto: %v", err)
 	}
@@ -39,10 +86,13 @@ func StartNodeServer(nodeID string) {
 		log.Fatalf("Error al iniciar el servidor del nodo: %v", err)
 	}
 
-	s := grpc.NewServer()
-	node := newNodeServer()
+	// Los permisos y órdenes del tracker se verifican en un interceptor, antes de atender cualquier solicitud
+	opts := append(node.credentials.ServerOptions(), grpc.ChainUnaryInterceptor(node.accessInterceptor))
+	s := grpc.NewServer(opts...)
 	pb.RegisterNodeServiceServer(s, node)
 
+	go node.choker.run()
+
 	log.Printf("Nodo escuchando en %s...", port)
 	if err := s.Serve(lis); err != nil {
 		log.Fatalf("Error al correr el servidor del nodo: %v", err)
@@ -51,12 +101,17 @@ func StartNodeServer(nodeID string) {
 
 // RequestChunk maneja la solicitud de un chunk desde otro nodo
 func (s *nodeServer) RequestChunk(ctx context.Context, req *pb.ChunkRequest) (*pb.ChunkResponse, error) {
-	s.mu.Lock()
-	defer s.mu.Unlock()
-
 	chunkID := req.ChunkId
-	data, exists := s.chunks[chunkID]
 
+	s.mu.Lock()
+	data, exists, err := s.store.get(chunkID)
+	proof := s.proofs[chunkID]
+	s.mu.Unlock()
+
+	if err != nil {
+		log.Printf("No se pudo leer el chunk %s: %v", chunkID, err)
+		return nil, status.Errorf(codes.DataLoss, "el chunk %s no se pudo leer", chunkID)
+	}
 	if !exists {
 		log.Printf("El chunk %s no está disponible en este nodo", chunkID)
 		return &pb.ChunkResponse{
@@ -64,9 +119,20 @@ func (s *nodeServer) RequestChunk(ctx context.Context, req *pb.ChunkRequest) (*p
 		}, nil
 	}
 
+	// Solo se sirve a los peers que están unchoked
+	if !s.choker.allowUpload(req.NodeId) {
+		log.Printf("Solicitud del chunk %s rechazada: peer %s en estado choked", chunkID, req.NodeId)
+		return nil, status.Errorf(codes.Unavailable, "peer %s en estado choked", req.NodeId)
+	}
+
+	// Esperar a que los límites de subida permitan enviar el chunk
+	s.upload.wait(req.NodeId, len(data))
+	s.choker.recordUpload(req.NodeId, len(data))
+
 	log.Printf("Solicitud recibida para el chunk %s", chunkID)
 	return &pb.ChunkResponse{
 		ChunkData: data,
+		Proof:     proof,
 		Message:   fmt.Sprintf("Chunk %s enviado correctamente", chunkID),
 	}, nil
 }
@@ -76,10 +142,146 @@ func (s *nodeServer) StoreChunk(ctx context.Context, req *pb.StoreChunkRequest)
 	s.mu.Lock()
 	defer s.mu.Unlock()
 
-	s.chunks[req.ChunkId] = req.ChunkData
+	sealed, err := s.store.seal(req.ChunkId, req.ChunkData)
+	if err != nil {
+		log.Printf("No se pudo cifrar el chunk %s: %v", req.ChunkId, err)
+		return nil, status.Errorf(codes.Internal, "no se pudo guardar el chunk %s", req.ChunkId)
+	}
+
+	// Verificar que el chunk quepa en la cuota, descontando la versión anterior o la copia en cuarentena si existen
+	used := s.used - s.store.size(req.ChunkId) - int64(len(s.quarantine[req.ChunkId])) + int64(len(sealed))
+	if s.quota > 0 && used > s.quota {
+		log.Printf("Chunk %s rechazado: se excedería la cuota de %d bytes", req.ChunkId, s.quota)
+		return nil, status.Errorf(codes.ResourceExhausted, "cuota de almacenamiento excedida (%d/%d bytes)", used, s.quota)
+	}
+
+	if err := s.store.put(req.ChunkId, sealed, req.Proof); err != nil {
+		log.Printf("No se pudo guardar el chunk %s: %v", req.ChunkId, err)
+		return nil, status.Errorf(codes.Internal, "no se pudo guardar el chunk %s", req.ChunkId)
+	}
+	s.hashes[req.ChunkId] = sha256.Sum256(req.ChunkData)
+	s.proofs[req.ChunkId] = req.Proof
+	delete(s.quarantine, req.ChunkId)
+	s.used = used
 	log.Printf("Chunk %s almacenado correctamente en el nodo", req.ChunkId)
 	return &pb.StoreChunkResponse{
 		Message: fmt.Sprintf("Chunk %s almacenado correctamente", req.ChunkId),
 	}, nil
 
 }
+
+// SetBandwidthLimit cambia en caliente un límite de subida o descarga en bytes por segundo.
+// Si peerID está vacío se cambia el límite global; AllPeers cambia el límite por defecto de cada peer.
+func (s *nodeServer) SetBandwidthLimit(direction, peerID string, rate int64) error {
+	var limiter *bandwidthLimiter
+	switch direction {
+	case DirectionUpload:
+		limiter = s.upload
+	case DirectionDownload:
+		limiter = s.download
+	default:
+		return fmt.Errorf("dirección desconocida: %s", direction)
+	}
+
+	if peerID == "" {
+		limiter.setGlobal(rate)
+	} else {
+		limiter.setPeer(peerID, rate)
+	}
+	log.Printf("Límite de %s para %q cambiado a %s", direction, peerID, formatRate(rate))
+	return nil
+}
+
+// BandwidthLimits devuelve un resumen de los límites de subida y descarga.
+func (s *nodeServer) BandwidthLimits() string {
+	return fmt.Sprintf("subida: %s\ndescarga: %s", s.upload.describe(), s.download.describe())
+}
+
+// ReplicateChunk copia un chunk almacenado hacia los nodos indicados por el tracker
+func (s *nodeServer) ReplicateChunk(ctx context.Context, req *pb.ReplicateChunkRequest) (*pb.ReplicateChunkResponse, error) {
+	s.mu.Lock()
+	data, exists, err := s.store.get(req.ChunkId)
+	proof := s.proofs[req.ChunkId]
+	s.mu.Unlock()
+
+	if err != nil || !exists {
+		return nil, status.Errorf(codes.NotFound, "el chunk %s no está disponible", req.ChunkId)
+	}
+
+	var stored []string
+	for _, target := range req.TargetNodes {
+		chunk := &pb.StoreChunkRequest{ChunkId: req.ChunkId, ChunkData: data, Proof: proof, NodeId: s.nodeID, Token: req.Token}
+		if err := s.SendChunkToNode(target, chunk); err == nil {
+			stored = append(stored, target)
+		}
+	}
+
+	log.Printf("Chunk %s re-replicado en %d de %d nodos", req.ChunkId, len(stored), len(req.TargetNodes))
+	return &pb.ReplicateChunkResponse{
+		Message:     fmt.Sprintf("Chunk %s copiado en %d nodos", req.ChunkId, len(stored)),
+		StoredNodes: stored,
+	}, nil
+}
+
+// DeleteChunk borra un chunk almacenado y libera su espacio. Borrar un chunk que no está
+// no es un error, para que el tracker pueda reintentar sin problemas.
+func (s *nodeServer) DeleteChunk(ctx context.Context, req *pb.DeleteChunkRequest) (*pb.DeleteChunkResponse, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if !s.removeChunk(req.ChunkId) {
+		return &pb.DeleteChunkResponse{Message: fmt.Sprintf("El chunk %s no estaba almacenado", req.ChunkId)}, nil
+	}
+	return &pb.DeleteChunkResponse{Message: fmt.Sprintf("Chunk %s borrado correctamente", req.ChunkId)}, nil
+}
+
+// Challenge demuestra que el nodo conserva un chunk devolviendo el bloque pedido con su
+// prueba de Merkle, que el tracker verifica contra la raíz publicada en el manifiesto.
+func (s *nodeServer) Challenge(ctx context.Context, req *pb.ChallengeRequest) (*pb.ChallengeResponse, error) {
+	s.mu.Lock()
+	data, exists, err := s.store.get(req.ChunkId)
+	s.mu.Unlock()
+
+	if err != nil || !exists {
+		return nil, status.Errorf(codes.NotFound, "el chunk %s no está disponible", req.ChunkId)
+	}
+	if req.Block < 0 || req.Block >= security.ChunkBlocks(int64(len(data))) {
+		return nil, status.Errorf(codes.InvalidArgument, "el chunk %s no tiene el bloque %d", req.ChunkId, req.Block)
+	}
+
+	_, block, proof := security.ProveBlock(data, req.Block)
+	return &pb.ChallengeResponse{BlockData: block, Proof: proof}, nil
+}
+
+// removeChunk borra un chunk y libera su espacio. Debe llamarse con s.mu tomado.
+func (s *nodeServer) removeChunk(chunkID string) bool {
+	size := s.store.size(chunkID) + int64(len(s.quarantine[chunkID]))
+	stored := s.store.has(chunkID)
+	_, quarantined := s.quarantine[chunkID]
+	if !stored && !quarantined {
+		return false
+	}
+	s.store.take(chunkID)
+	delete(s.hashes, chunkID)
+	delete(s.proofs, chunkID)
+	delete(s.quarantine, chunkID)
+	s.used -= size
+	log.Printf("Chunk %s borrado del nodo", chunkID)
+	return true
+}
+
+// Capacity devuelve la capacidad y el espacio libre del nodo en bytes.
+func (s *nodeServer) Capacity() (capacity, free int64) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.quota <= 0 {
+		return 0, 0
+	}
+	return s.quota, s.quota - s.used
+}
+
+// Labels devuelve los dominios de falla declarados por el nodo.
+func (s *nodeServer) Labels() map[string]string {
+	return s.labels
+}
@@ -0,0 +1,315 @@
+package node
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+
+	"P2P_BitTorrent/pb"
+
+	"google.golang.org/protobuf/proto"
+)
+
+// chunkStore guarda los chunks del nodo, en memoria o, si tiene carpeta, en disco: un archivo
+// por chunk con sus datos y su prueba de Merkle, para que sobrevivan a un reinicio. Si tiene
+// claves, cada chunk se cifra en reposo con AES-GCM usando su chunk_id como dato asociado, y
+// se guarda como id de clave (4 bytes) || nonce || datos cifrados; así lo que queda en el
+// disco no se puede leer sin el archivo de claves, que se guarda aparte. No es seguro para
+// uso concurrente: el nodo lo usa con s.mu tomado.
+type chunkStore struct {
+	dir    string            // Carpeta de los chunks ("" = en memoria)
+	chunks map[string][]byte // Chunks tal como se guardan (cifrados si hay claves), si están en memoria
+	sizes  map[string]int64  // Bytes que ocupa cada chunk guardado
+	keys   *StoreKeys        // Claves de cifrado en reposo (nil = sin cifrar)
+}
+
+// newChunkStore crea un almacén vacío en dir, o en memoria si dir está vacío; keys puede ser
+// nil para guardar los chunks sin cifrar. Los chunks que ya estén en dir se leen con load.
+func newChunkStore(dir string, keys *StoreKeys) *chunkStore {
+	return &chunkStore{dir: dir, chunks: make(map[string][]byte), sizes: make(map[string]int64), keys: keys}
+}
+
+// Extensión de los archivos de chunks dentro de la carpeta del almacén.
+const chunkFileExt = ".chunk"
+
+// Archivo de la carpeta del almacén que indica si sus chunks están cifrados.
+const storeModeFile = "mode"
+
+// errMissingStoreKey indica que un chunk está cifrado con una clave que no está en el archivo de claves.
+var errMissingStoreKey = errors.New("la clave del chunk no está en el archivo de claves")
+
+// path devuelve el archivo de un chunk. El nombre se deriva del chunk_id en hexadecimal
+// porque el chunk_id llega de otros nodos y no debe poder salir de la carpeta.
+func (c *chunkStore) path(chunkID string) string {
+	return filepath.Join(c.dir, hex.EncodeToString([]byte(chunkID))+chunkFileExt)
+}
+
+// load crea la carpeta del almacén si no existe y lee los chunks que quedaron de una ejecución
+// anterior. Devuelve los registros leídos, con los datos tal como están guardados.
+func (c *chunkStore) load() ([]*pb.StoreChunkRequest, error) {
+	if c.dir == "" {
+		return nil, nil
+	}
+	if err := os.MkdirAll(c.dir, 0o700); err != nil {
+		return nil, fmt.Errorf("no se pudo crear la carpeta de chunks: %v", err)
+	}
+
+	// Una carpeta con chunks cifrados no se puede usar sin claves, ni al revés
+	mode := "plain"
+	if c.keys != nil {
+		mode = "encrypted"
+	}
+	previous, err := os.ReadFile(filepath.Join(c.dir, storeModeFile))
+	switch {
+	case errors.Is(err, os.ErrNotExist):
+		if err := os.WriteFile(filepath.Join(c.dir, storeModeFile), []byte(mode), 0o600); err != nil {
+			return nil, err
+		}
+	case err != nil:
+		return nil, err
+	case string(previous) != mode:
+		return nil, fmt.Errorf("los chunks de %s se guardaron en modo %s y el nodo está en modo %s", c.dir, previous, mode)
+	}
+	entries, err := os.ReadDir(c.dir)
+	if err != nil {
+		return nil, fmt.Errorf("no se pudo leer la carpeta de chunks: %v", err)
+	}
+
+	var records []*pb.StoreChunkRequest
+	for _, entry := range entries {
+		if entry.IsDir() || filepath.Ext(entry.Name()) != chunkFileExt {
+			continue
+		}
+		record, err := c.readFile(filepath.Join(c.dir, entry.Name()))
+		if err != nil {
+			log.Printf("Se ignora el archivo de chunk %s: %v", entry.Name(), err)
+			continue
+		}
+		c.sizes[record.ChunkId] = int64(len(record.ChunkData))
+		records = append(records, record)
+	}
+	return records, nil
+}
+
+// readFile lee el registro de un chunk guardado en disco.
+func (c *chunkStore) readFile(path string) (*pb.StoreChunkRequest, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	record := &pb.StoreChunkRequest{}
+	if err := proto.Unmarshal(data, record); err != nil {
+		return nil, err
+	}
+	if c.path(record.ChunkId) != path {
+		return nil, errors.New("el nombre del archivo no corresponde al chunk que contiene")
+	}
+	return record, nil
+}
+
+// writeFile guarda el registro de un chunk en disco. Se escribe en un archivo temporal y se
+// renombra, para que un corte a mitad no deje el chunk a medio escribir.
+func (c *chunkStore) writeFile(record *pb.StoreChunkRequest) error {
+	data, err := proto.Marshal(record)
+	if err != nil {
+		return err
+	}
+	path := c.path(record.ChunkId)
+	if err := os.WriteFile(path+".tmp", data, 0o600); err != nil {
+		return err
+	}
+	return os.Rename(path+".tmp", path)
+}
+
+// seal prepara un chunk para guardarlo, cifrándolo con la clave vigente si hay claves.
+func (c *chunkStore) seal(chunkID string, data []byte) ([]byte, error) {
+	if c.keys == nil {
+		return data, nil
+	}
+	id, aead := c.keys.current()
+	sealed := make([]byte, 4+aead.NonceSize(), 4+aead.NonceSize()+len(data)+aead.Overhead())
+	binary.BigEndian.PutUint32(sealed, id)
+	if _, err := rand.Read(sealed[4:]); err != nil {
+		return nil, err
+	}
+	return aead.Seal(sealed, sealed[4:], data, []byte(chunkID)), nil
+}
+
+// open recupera el contenido de un chunk guardado.
+func (c *chunkStore) open(chunkID string, stored []byte) ([]byte, error) {
+	if c.keys == nil {
+		return stored, nil
+	}
+	if len(stored) < 4 {
+		return nil, errors.New("chunk cifrado truncado")
+	}
+	id := binary.BigEndian.Uint32(stored)
+	aead, exists := c.keys.aeads[id]
+	if !exists {
+		return nil, fmt.Errorf("%w: el chunk está cifrado con la clave %d", errMissingStoreKey, id)
+	}
+	if len(stored) < 4+aead.NonceSize() {
+		return nil, errors.New("chunk cifrado truncado")
+	}
+	nonce, ciphertext := stored[4:4+aead.NonceSize()], stored[4+aead.NonceSize():]
+	data, err := aead.Open(nil, nonce, ciphertext, []byte(chunkID))
+	if err != nil {
+		return nil, errors.New("el chunk cifrado no pasa la autenticación")
+	}
+	return data, nil
+}
+
+// put guarda un chunk ya preparado con seal, junto con su prueba de Merkle si el almacén
+// está en disco.
+func (c *chunkStore) put(chunkID string, sealed []byte, proof []*pb.MerkleStep) error {
+	if c.dir == "" {
+		c.chunks[chunkID] = sealed
+	} else if err := c.writeFile(&pb.StoreChunkRequest{ChunkId: chunkID, ChunkData: sealed, Proof: proof}); err != nil {
+		return err
+	}
+	c.sizes[chunkID] = int64(len(sealed))
+	return nil
+}
+
+// stored devuelve un chunk tal como está guardado, sin descifrar.
+func (c *chunkStore) stored(chunkID string) ([]byte, bool, error) {
+	if _, exists := c.sizes[chunkID]; !exists {
+		return nil, false, nil
+	}
+	if c.dir == "" {
+		return c.chunks[chunkID], true, nil
+	}
+	record, err := c.readFile(c.path(chunkID))
+	if err != nil {
+		return nil, true, err
+	}
+	return record.ChunkData, true, nil
+}
+
+// get devuelve el contenido de un chunk. Un error indica que el chunk existe pero no se
+// pudo leer o descifrar.
+func (c *chunkStore) get(chunkID string) ([]byte, bool, error) {
+	stored, exists, err := c.stored(chunkID)
+	if !exists || err != nil {
+		return nil, exists, err
+	}
+	data, err := c.open(chunkID, stored)
+	return data, true, err
+}
+
+// has indica si el chunk está guardado.
+func (c *chunkStore) has(chunkID string) bool {
+	_, exists := c.sizes[chunkID]
+	return exists
+}
+
+// take quita un chunk del almacén y devuelve lo que estaba guardado, sin descifrar. En disco
+// también borra el archivo de un chunk que se dejó de lado con forget.
+func (c *chunkStore) take(chunkID string) []byte {
+	stored, _, err := c.stored(chunkID)
+	if err != nil {
+		log.Printf("No se pudo leer el chunk %s antes de quitarlo: %v", chunkID, err)
+	}
+	if c.dir != "" {
+		if err := os.Remove(c.path(chunkID)); err != nil && !errors.Is(err, os.ErrNotExist) {
+			log.Printf("No se pudo borrar el archivo del chunk %s: %v", chunkID, err)
+		}
+	}
+	c.forget(chunkID)
+	return stored
+}
+
+// forget quita un chunk del almacén sin borrar su archivo.
+func (c *chunkStore) forget(chunkID string) {
+	delete(c.chunks, chunkID)
+	delete(c.sizes, chunkID)
+}
+
+// size devuelve los bytes que ocupa un chunk guardado, o 0 si no está.
+func (c *chunkStore) size(chunkID string) int64 {
+	return c.sizes[chunkID]
+}
+
+// ids devuelve los IDs de los chunks guardados.
+func (c *chunkStore) ids() []string {
+	chunkIDs := make([]string, 0, len(c.sizes))
+	for chunkID := range c.sizes {
+		chunkIDs = append(chunkIDs, chunkID)
+	}
+	return chunkIDs
+}
+
+// reseal vuelve a cifrar todos los chunks con la clave vigente, conservando sus pruebas de
+// Merkle. Los que no se pueden leer o descifrar se devuelven para que el nodo los trate como
+// corruptos.
+func (c *chunkStore) reseal(proofs map[string][]*pb.MerkleStep) []string {
+	var failed []string
+	for _, chunkID := range c.ids() {
+		stored, _, err := c.stored(chunkID)
+		var data []byte
+		if err == nil {
+			data, err = c.open(chunkID, stored)
+		}
+		if err == nil {
+			stored, err = c.seal(chunkID, data)
+		}
+		if err == nil {
+			err = c.put(chunkID, stored, proofs[chunkID])
+		}
+		if err != nil {
+			failed = append(failed, chunkID)
+		}
+	}
+	return failed
+}
+
+// newStoreCipher construye el cifrador AES-GCM de una clave de almacenamiento.
+func newStoreCipher(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+// LoadStoredChunks lee los chunks que el nodo guardó en su carpeta en una ejecución anterior,
+// para volver a servirlos y reportarlos en su inventario. Los que no pasan la autenticación se
+// ponen en cuarentena sin borrarlos del disco; si falta la clave de alguno se devuelve un
+// error, porque el archivo de claves es el equivocado. Devuelve la cantidad de chunks
+// recuperados.
+func (s *nodeServer) LoadStoredChunks() (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	records, err := s.store.load()
+	if err != nil {
+		return 0, err
+	}
+	loaded := 0
+	for _, record := range records {
+		s.used += int64(len(record.ChunkData))
+		data, err := s.store.open(record.ChunkId, record.ChunkData)
+		if errors.Is(err, errMissingStoreKey) {
+			return loaded, fmt.Errorf("chunk %s: %v", record.ChunkId, err)
+		}
+		if err != nil {
+			// Se deja el archivo: con otro archivo de claves el chunk puede volver a leerse
+			log.Printf("Chunk %s en cuarentena: %v", record.ChunkId, err)
+			s.quarantine[record.ChunkId] = record.ChunkData
+			s.store.forget(record.ChunkId)
+			continue
+		}
+		s.hashes[record.ChunkId] = sha256.Sum256(data)
+		s.proofs[record.ChunkId] = record.Proof
+		loaded++
+	}
+	return loaded, nil
+}
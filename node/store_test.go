@@ -0,0 +1,153 @@
+package node
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"P2P_BitTorrent/pb"
+)
+
+func TestChunkStoreOnDisk(t *testing.T) {
+	data := bytes.Repeat([]byte("contenido del chunk "), 100)
+	proof := []*pb.MerkleStep{{Hash: bytes.Repeat([]byte{7}, 32), Left: true}}
+
+	tests := []struct {
+		name    string
+		encrypt bool
+		rotate  bool
+	}{
+		{name: "sin cifrar"},
+		{name: "cifrado", encrypt: true},
+		{name: "cifrado con la clave rotada", encrypt: true, rotate: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := filepath.Join(t.TempDir(), "chunks")
+			var keys *StoreKeys
+			if tt.encrypt {
+				var err error
+				if keys, err = LoadStoreKeys(filepath.Join(t.TempDir(), "store.key")); err != nil {
+					t.Fatalf("LoadStoreKeys: %v", err)
+				}
+			}
+
+			s := NewNodeServer("10.0.0.1:50000", Config{DataDir: dir, StoreKeys: keys})
+			if _, err := s.LoadStoredChunks(); err != nil {
+				t.Fatalf("LoadStoredChunks: %v", err)
+			}
+			for _, chunkID := range []string{"archivo-1", "../../archivo-2"} {
+				if _, err := s.StoreChunk(context.Background(), &pb.StoreChunkRequest{ChunkId: chunkID, ChunkData: data, Proof: proof}); err != nil {
+					t.Fatalf("StoreChunk(%s): %v", chunkID, err)
+				}
+			}
+			if tt.rotate {
+				if _, err := s.RotateStoreKey(); err != nil {
+					t.Fatalf("RotateStoreKey: %v", err)
+				}
+			}
+			s.removeChunk("../../archivo-2")
+
+			// Lo que queda en el disco son solo los archivos de la carpeta, y cifrados si hay claves
+			files, err := os.ReadDir(dir)
+			if err != nil {
+				t.Fatalf("ReadDir: %v", err)
+			}
+			chunkFiles := 0
+			for _, file := range files {
+				if filepath.Ext(file.Name()) != chunkFileExt {
+					continue
+				}
+				chunkFiles++
+				content, err := os.ReadFile(filepath.Join(dir, file.Name()))
+				if err != nil {
+					t.Fatalf("ReadFile: %v", err)
+				}
+				if plain := bytes.Contains(content, data); plain == tt.encrypt {
+					t.Errorf("el archivo %s contiene el chunk sin cifrar = %v", file.Name(), plain)
+				}
+			}
+			if chunkFiles != 1 {
+				t.Errorf("hay %d archivos de chunks en la carpeta, se esperaba 1", chunkFiles)
+			}
+
+			// Un nodo nuevo con la misma carpeta y las mismas claves recupera el chunk y su prueba
+			restarted := NewNodeServer("10.0.0.1:50000", Config{DataDir: dir, StoreKeys: keys})
+			loaded, err := restarted.LoadStoredChunks()
+			if err != nil || loaded != 1 {
+				t.Fatalf("LoadStoredChunks = %d, %v; se esperaba 1 chunk", loaded, err)
+			}
+			got, exists, err := restarted.store.get("archivo-1")
+			if err != nil || !exists || !bytes.Equal(got, data) {
+				t.Errorf("chunk recuperado: existe=%v, error=%v, igual=%v", exists, err, bytes.Equal(got, data))
+			}
+			if !reflect.DeepEqual(restarted.proofs["archivo-1"][0].Hash, proof[0].Hash) {
+				t.Error("no se recuperó la prueba de Merkle del chunk")
+			}
+			if restarted.used != s.used {
+				t.Errorf("espacio ocupado tras reiniciar %d, se esperaba %d", restarted.used, s.used)
+			}
+		})
+	}
+}
+
+func TestChunkStoreRejectsWrongKeys(t *testing.T) {
+	dir := t.TempDir()
+	keys, err := LoadStoreKeys(filepath.Join(t.TempDir(), "store.key"))
+	if err != nil {
+		t.Fatalf("LoadStoreKeys: %v", err)
+	}
+	s := NewNodeServer("10.0.0.1:50000", Config{DataDir: dir, StoreKeys: keys})
+	if _, err := s.LoadStoredChunks(); err != nil {
+		t.Fatalf("LoadStoredChunks: %v", err)
+	}
+	if _, err := s.StoreChunk(context.Background(), &pb.StoreChunkRequest{ChunkId: "archivo-1", ChunkData: []byte("datos")}); err != nil {
+		t.Fatalf("StoreChunk: %v", err)
+	}
+	sameID, err := LoadStoreKeys(filepath.Join(t.TempDir(), "store.key"))
+	if err != nil {
+		t.Fatalf("LoadStoreKeys: %v", err)
+	}
+	otherID, err := LoadStoreKeys(filepath.Join(t.TempDir(), "store.key"))
+	if err != nil {
+		t.Fatalf("LoadStoreKeys: %v", err)
+	}
+	if err := otherID.add(); err != nil {
+		t.Fatalf("add: %v", err)
+	}
+	otherID.retire()
+
+	tests := []struct {
+		name    string
+		keys    *StoreKeys
+		wantErr bool
+	}{
+		{name: "sin claves", keys: nil, wantErr: true},
+		{name: "otras claves con otro id", keys: otherID, wantErr: true},
+		{name: "otras claves con el mismo id", keys: sameID},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			restarted := NewNodeServer("10.0.0.1:50000", Config{DataDir: dir, StoreKeys: tt.keys})
+			loaded, err := restarted.LoadStoredChunks()
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("LoadStoredChunks: error %v, se esperaba error: %v", err, tt.wantErr)
+			}
+			if loaded != 0 || (!tt.wantErr && len(restarted.store.ids()) != 0) {
+				t.Errorf("se recuperaron chunks con claves equivocadas")
+			}
+			if _, err := os.Stat(restarted.store.path("archivo-1")); err != nil {
+				t.Errorf("el chunk se borró del disco: %v", err)
+			}
+		})
+	}
+
+	// Con las claves correctas el chunk se vuelve a leer
+	restarted := NewNodeServer("10.0.0.1:50000", Config{DataDir: dir, StoreKeys: keys})
+	if loaded, err := restarted.LoadStoredChunks(); err != nil || loaded != 1 {
+		t.Errorf("LoadStoredChunks con las claves correctas = %d, %v; se esperaba 1 chunk", loaded, err)
+	}
+}
@@ -0,0 +1,162 @@
+package node
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// Tamaño de las claves AES-256 del almacén de chunks.
+const StoreKeySize = 32
+
+// StoreKeys son las claves con las que el nodo cifra sus chunks en reposo. Se guardan en un
+// archivo local, una por línea con el formato "id clave_hex"; la de id más alto es la
+// vigente y las anteriores solo se usan para leer chunks que todavía no se re-cifraron.
+type StoreKeys struct {
+	path      string
+	currentID uint32
+	keys      map[uint32][]byte
+	aeads     map[uint32]cipher.AEAD
+}
+
+// LoadStoreKeys carga las claves del almacén desde path; si el archivo no existe, lo crea
+// con una clave nueva.
+func LoadStoreKeys(path string) (*StoreKeys, error) {
+	k := &StoreKeys{path: path, keys: make(map[uint32][]byte), aeads: make(map[uint32]cipher.AEAD)}
+
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		if err := k.add(); err != nil {
+			return nil, err
+		}
+		return k, k.save()
+	}
+	if err != nil {
+		return nil, fmt.Errorf("no se pudo leer el archivo de claves del almacén: %v", err)
+	}
+
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) == 0 {
+			continue
+		}
+		if len(fields) != 2 {
+			return nil, fmt.Errorf("línea inválida en el archivo de claves %s", path)
+		}
+		id, err := strconv.ParseUint(fields[0], 10, 32)
+		if err != nil {
+			return nil, fmt.Errorf("id de clave inválido en %s: %s", path, fields[0])
+		}
+		key, err := hex.DecodeString(fields[1])
+		if err != nil || len(key) != StoreKeySize {
+			return nil, fmt.Errorf("la clave %d de %s no es una clave AES-256 en hexadecimal", id, path)
+		}
+		if err := k.set(uint32(id), key); err != nil {
+			return nil, err
+		}
+	}
+	if len(k.keys) == 0 {
+		return nil, fmt.Errorf("el archivo de claves %s está vacío", path)
+	}
+	return k, nil
+}
+
+// set agrega una clave y la marca como vigente si es la de id más alto.
+func (k *StoreKeys) set(id uint32, key []byte) error {
+	aead, err := newStoreCipher(key)
+	if err != nil {
+		return err
+	}
+	k.keys[id] = key
+	k.aeads[id] = aead
+	if id > k.currentID {
+		k.currentID = id
+	}
+	return nil
+}
+
+// add genera una clave nueva y la deja como vigente.
+func (k *StoreKeys) add() error {
+	key := make([]byte, StoreKeySize)
+	if _, err := rand.Read(key); err != nil {
+		return err
+	}
+	return k.set(k.currentID+1, key)
+}
+
+// current devuelve el id y el cifrador de la clave vigente.
+func (k *StoreKeys) current() (uint32, cipher.AEAD) {
+	return k.currentID, k.aeads[k.currentID]
+}
+
+// retire descarta todas las claves salvo la vigente.
+func (k *StoreKeys) retire() {
+	for id := range k.keys {
+		if id != k.currentID {
+			delete(k.keys, id)
+			delete(k.aeads, id)
+		}
+	}
+}
+
+// save escribe las claves en el archivo, reemplazándolo de forma atómica.
+func (k *StoreKeys) save() error {
+	var buf bytes.Buffer
+	for id := uint32(1); id <= k.currentID; id++ {
+		if key, exists := k.keys[id]; exists {
+			fmt.Fprintf(&buf, "%d %x\n", id, key)
+		}
+	}
+	tmp := k.path + ".tmp"
+	if err := os.WriteFile(tmp, buf.Bytes(), 0600); err != nil {
+		return fmt.Errorf("no se pudo guardar el archivo de claves del almacén: %v", err)
+	}
+	if err := os.Rename(tmp, k.path); err != nil {
+		return fmt.Errorf("no se pudo guardar el archivo de claves del almacén: %v", err)
+	}
+	return nil
+}
+
+// RotateStoreKey genera una clave de almacén nueva, vuelve a cifrar con ella todos los
+// chunks y descarta las anteriores. La clave nueva se guarda antes de re-cifrar y las
+// viejas se borran del archivo al final, para que un corte a mitad no deje chunks ilegibles.
+// Devuelve la cantidad de chunks re-cifrados.
+func (s *nodeServer) RotateStoreKey() (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	keys := s.store.keys
+	if keys == nil {
+		return 0, errors.New("el nodo no cifra sus chunks en reposo")
+	}
+	if err := keys.add(); err != nil {
+		return 0, err
+	}
+	if err := keys.save(); err != nil {
+		return 0, err
+	}
+
+	// Los chunks que no se pueden descifrar ya estaban corruptos: el scrubber los pondrá en
+	// cuarentena y los reportará al tracker en su próxima pasada
+	failed := s.store.reseal(s.proofs)
+	if len(failed) > 0 {
+		log.Printf("%d chunks no se pudieron descifrar al rotar la clave del almacén", len(failed))
+	}
+
+	keys.retire()
+	if err := keys.save(); err != nil {
+		return 0, err
+	}
+	resealed := len(s.store.ids()) - len(failed)
+	log.Printf("Clave del almacén rotada a la %d: %d chunks re-cifrados", keys.currentID, resealed)
+	return resealed, nil
+}
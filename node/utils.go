@@ -3,18 +3,44 @@ package node
 import (
 	"P2P_BitTorrent/pb"
 	"fmt"
+	"strings"
 )
 
 // findChunk busca un chunk específico en la lista de chunks por su ID
-func FindChunk(chunks []pb.StoreChunkRequest, chunkID string) *pb.StoreChunkRequest {
+func FindChunk(chunks []*pb.StoreChunkRequest, chunkID string) *pb.StoreChunkRequest {
 	for _, chunk := range chunks {
 		if chunk.ChunkId == chunkID {
-			return &chunk
+			return chunk
 		}
 	}
 	return nil
 }
 
+// ParseOptions separa los argumentos de un comando en posicionales y opciones "--nombre valor".
+// Las opciones listadas en flags no llevan valor y quedan con valor vacío.
+func ParseOptions(args []string, flags ...string) ([]string, map[string]string) {
+	var positional []string
+	options := make(map[string]string)
+	for i := 0; i < len(args); i++ {
+		if !strings.HasPrefix(args[i], "--") {
+			positional = append(positional, args[i])
+			continue
+		}
+		name := strings.TrimPrefix(args[i], "--")
+		isFlag := false
+		for _, flag := range flags {
+			isFlag = isFlag || flag == name
+		}
+		if !isFlag && i+1 < len(args) {
+			options[name] = args[i+1]
+			i++
+		} else {
+			options[name] = ""
+		}
+	}
+	return positional, options
+}
+
 // Convierte el tamaño del archivo de string a int
 func ParseSize(size string) (int, error) {
 	var fileSize int
@@ -25,13 +51,32 @@ func ParseSize(size string) (int, error) {
 	return fileSize, nil
 }
 
-// Función para crear chunks de datos simulados
-func CreateChunks(fileName string, totalSize, chunkSize int) []pb.StoreChunkRequest {
-	var chunks []pb.StoreChunkRequest
+// ParseVersion separa el nombre de archivo y la versión en referencias como "archivo.txt@v2".
+// Sin versión devuelve 0, que el tracker interpreta como la más reciente.
+func ParseVersion(ref string) (string, int32, error) {
+	i := strings.LastIndex(ref, "@v")
+	if i < 0 {
+		return ref, 0, nil
+	}
+	var version int32
+	if _, err := fmt.Sscanf(ref[i+2:], "%d", &version); err != nil || version <= 0 {
+		return "", 0, fmt.Errorf("versión inválida en %s, ejemplo: archivo.txt@v2", ref)
+	}
+	return ref[:i], version, nil
+}
+
+// ChunkID deriva el identificador con el que los nodos guardan un chunk a partir de su clave
+func ChunkID(key *pb.ChunkKey) string {
+	return fmt.Sprintf("%s-%d", key.FileId, key.Index)
+}
+
+// Función para crear chunks de datos simulados del archivo con el identificador indicado
+func CreateChunks(fileID string, totalSize, chunkSize int) []*pb.StoreChunkRequest {
+	var chunks []*pb.StoreChunkRequest
 	numChunks := totalSize / chunkSize
 	for i := 0; i < numChunks; i++ {
-		chunkID := fmt.Sprintf("%s-%d", fileName, i+1)
-		chunks = append(chunks, pb.StoreChunkRequest{
+		chunkID := ChunkID(&pb.ChunkKey{FileId: fileID, Index: int32(i + 1)})
+		chunks = append(chunks, &pb.StoreChunkRequest{
 			ChunkId:   chunkID,
 			ChunkData: []byte(fmt.Sprintf("Datos del chunk %s", chunkID)), // Datos simulados
 		})
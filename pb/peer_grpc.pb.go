@@ -19,10 +19,23 @@ import (
 const _ = grpc.SupportPackageIsVersion9
 
 const (
-	TrackerService_JoinNetwork_FullMethodName  = "/peer.TrackerService/JoinNetwork"
-	TrackerService_LeaveNetwork_FullMethodName = "/peer.TrackerService/LeaveNetwork"
-	TrackerService_GetFileNodes_FullMethodName = "/peer.TrackerService/GetFileNodes"
-	TrackerService_PutFile_FullMethodName      = "/peer.TrackerService/PutFile"
+	TrackerService_JoinNetwork_FullMethodName         = "/peer.TrackerService/JoinNetwork"
+	TrackerService_LeaveNetwork_FullMethodName        = "/peer.TrackerService/LeaveNetwork"
+	TrackerService_GetFileNodes_FullMethodName        = "/peer.TrackerService/GetFileNodes"
+	TrackerService_PutFile_FullMethodName             = "/peer.TrackerService/PutFile"
+	TrackerService_Heartbeat_FullMethodName           = "/peer.TrackerService/Heartbeat"
+	TrackerService_GetDomainReport_FullMethodName     = "/peer.TrackerService/GetDomainReport"
+	TrackerService_ListVersions_FullMethodName        = "/peer.TrackerService/ListVersions"
+	TrackerService_DeleteFile_FullMethodName          = "/peer.TrackerService/DeleteFile"
+	TrackerService_ReportInventory_FullMethodName     = "/peer.TrackerService/ReportInventory"
+	TrackerService_ReportCorruptChunks_FullMethodName = "/peer.TrackerService/ReportCorruptChunks"
+	TrackerService_PublishManifest_FullMethodName     = "/peer.TrackerService/PublishManifest"
+	TrackerService_UpdateFileAcl_FullMethodName       = "/peer.TrackerService/UpdateFileAcl"
+	TrackerService_UpdateNamespace_FullMethodName     = "/peer.TrackerService/UpdateNamespace"
+	TrackerService_ListNamespaces_FullMethodName      = "/peer.TrackerService/ListNamespaces"
+	TrackerService_GetUsage_FullMethodName            = "/peer.TrackerService/GetUsage"
+	TrackerService_ListFiles_FullMethodName           = "/peer.TrackerService/ListFiles"
+	TrackerService_SearchFiles_FullMethodName         = "/peer.TrackerService/SearchFiles"
 )
 
 // TrackerServiceClient is the client API for TrackerService service.
@@ -39,6 +52,32 @@ type TrackerServiceClient interface {
 	GetFileNodes(ctx context.Context, in *FileRequest, opts ...grpc.CallOption) (*FileNodesResponse, error)
 	// Manejar la subida de un archivo, fragmentar y distribuir chunks.
 	PutFile(ctx context.Context, in *PutRequest, opts ...grpc.CallOption) (*PutResponse, error)
+	// Reportar periódicamente la capacidad y el espacio libre del nodo.
+	Heartbeat(ctx context.Context, in *HeartbeatRequest, opts ...grpc.CallOption) (*HeartbeatResponse, error)
+	// Listar los chunks cuyas réplicas comparten un dominio de falla.
+	GetDomainReport(ctx context.Context, in *DomainReportRequest, opts ...grpc.CallOption) (*DomainReportResponse, error)
+	// Listar las versiones guardadas de un archivo.
+	ListVersions(ctx context.Context, in *FileRequest, opts ...grpc.CallOption) (*VersionsResponse, error)
+	// Eliminar un archivo de la red y liberar sus chunks en los nodos.
+	DeleteFile(ctx context.Context, in *DeleteFileRequest, opts ...grpc.CallOption) (*DeleteFileResponse, error)
+	// Reportar los chunks que almacena el nodo para reconciliarlos con el mapa del tracker.
+	ReportInventory(ctx context.Context, in *InventoryRequest, opts ...grpc.CallOption) (*InventoryResponse, error)
+	// Reportar chunks corruptos detectados por el scrubber del nodo.
+	ReportCorruptChunks(ctx context.Context, in *CorruptChunksRequest, opts ...grpc.CallOption) (*CorruptChunksResponse, error)
+	// Publicar el manifiesto firmado de un archivo recién subido.
+	PublishManifest(ctx context.Context, in *PublishManifestRequest, opts ...grpc.CallOption) (*PublishManifestResponse, error)
+	// Consultar o cambiar los permisos de un archivo (solo su dueño los puede cambiar).
+	UpdateFileAcl(ctx context.Context, in *AclRequest, opts ...grpc.CallOption) (*AclResponse, error)
+	// Crear un namespace o cambiar su cuota y sus permisos por defecto (solo su dueño).
+	UpdateNamespace(ctx context.Context, in *NamespaceRequest, opts ...grpc.CallOption) (*NamespaceResponse, error)
+	// Listar los namespaces con su uso.
+	ListNamespaces(ctx context.Context, in *ListNamespacesRequest, opts ...grpc.CallOption) (*ListNamespacesResponse, error)
+	// Consultar el espacio y el ancho de banda que usa un nodo, y sus cuotas.
+	GetUsage(ctx context.Context, in *UsageRequest, opts ...grpc.CallOption) (*UsageResponse, error)
+	// Listar los archivos de un namespace, opcionalmente los que empiezan con un prefijo.
+	ListFiles(ctx context.Context, in *ListFilesRequest, opts ...grpc.CallOption) (*ListFilesResponse, error)
+	// Buscar archivos de un namespace por subcadena o patrón glob.
+	SearchFiles(ctx context.Context, in *SearchFilesRequest, opts ...grpc.CallOption) (*ListFilesResponse, error)
 }
 
 type trackerServiceClient struct {
@@ -89,6 +128,136 @@ func (c *trackerServiceClient) PutFile(ctx context.Context, in *PutRequest, opts
 	return out, nil
 }
 
+func (c *trackerServiceClient) Heartbeat(ctx context.Context, in *HeartbeatRequest, opts ...grpc.CallOption) (*HeartbeatResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(HeartbeatResponse)
+	err := c.cc.Invoke(ctx, TrackerService_Heartbeat_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *trackerServiceClient) GetDomainReport(ctx context.Context, in *DomainReportRequest, opts ...grpc.CallOption) (*DomainReportResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DomainReportResponse)
+	err := c.cc.Invoke(ctx, TrackerService_GetDomainReport_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *trackerServiceClient) ListVersions(ctx context.Context, in *FileRequest, opts ...grpc.CallOption) (*VersionsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(VersionsResponse)
+	err := c.cc.Invoke(ctx, TrackerService_ListVersions_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *trackerServiceClient) DeleteFile(ctx context.Context, in *DeleteFileRequest, opts ...grpc.CallOption) (*DeleteFileResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DeleteFileResponse)
+	err := c.cc.Invoke(ctx, TrackerService_DeleteFile_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *trackerServiceClient) ReportInventory(ctx context.Context, in *InventoryRequest, opts ...grpc.CallOption) (*InventoryResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(InventoryResponse)
+	err := c.cc.Invoke(ctx, TrackerService_ReportInventory_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *trackerServiceClient) ReportCorruptChunks(ctx context.Context, in *CorruptChunksRequest, opts ...grpc.CallOption) (*CorruptChunksResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CorruptChunksResponse)
+	err := c.cc.Invoke(ctx, TrackerService_ReportCorruptChunks_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *trackerServiceClient) PublishManifest(ctx context.Context, in *PublishManifestRequest, opts ...grpc.CallOption) (*PublishManifestResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(PublishManifestResponse)
+	err := c.cc.Invoke(ctx, TrackerService_PublishManifest_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *trackerServiceClient) UpdateFileAcl(ctx context.Context, in *AclRequest, opts ...grpc.CallOption) (*AclResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(AclResponse)
+	err := c.cc.Invoke(ctx, TrackerService_UpdateFileAcl_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *trackerServiceClient) UpdateNamespace(ctx context.Context, in *NamespaceRequest, opts ...grpc.CallOption) (*NamespaceResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(NamespaceResponse)
+	err := c.cc.Invoke(ctx, TrackerService_UpdateNamespace_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *trackerServiceClient) ListNamespaces(ctx context.Context, in *ListNamespacesRequest, opts ...grpc.CallOption) (*ListNamespacesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListNamespacesResponse)
+	err := c.cc.Invoke(ctx, TrackerService_ListNamespaces_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *trackerServiceClient) GetUsage(ctx context.Context, in *UsageRequest, opts ...grpc.CallOption) (*UsageResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(UsageResponse)
+	err := c.cc.Invoke(ctx, TrackerService_GetUsage_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *trackerServiceClient) ListFiles(ctx context.Context, in *ListFilesRequest, opts ...grpc.CallOption) (*ListFilesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListFilesResponse)
+	err := c.cc.Invoke(ctx, TrackerService_ListFiles_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *trackerServiceClient) SearchFiles(ctx context.Context, in *SearchFilesRequest, opts ...grpc.CallOption) (*ListFilesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListFilesResponse)
+	err := c.cc.Invoke(ctx, TrackerService_SearchFiles_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // TrackerServiceServer is the server API for TrackerService service.
 // All implementations must embed UnimplementedTrackerServiceServer
 // for forward compatibility.
@@ -103,6 +272,32 @@ type TrackerServiceServer interface {
 	GetFileNodes(context.Context, *FileRequest) (*FileNodesResponse, error)
 	// Manejar la subida de un archivo, fragmentar y distribuir chunks.
 	PutFile(context.Context, *PutRequest) (*PutResponse, error)
+	// Reportar periódicamente la capacidad y el espacio libre del nodo.
+	Heartbeat(context.Context, *HeartbeatRequest) (*HeartbeatResponse, error)
+	// Listar los chunks cuyas réplicas comparten un dominio de falla.
+	GetDomainReport(context.Context, *DomainReportRequest) (*DomainReportResponse, error)
+	// Listar las versiones guardadas de un archivo.
+	ListVersions(context.Context, *FileRequest) (*VersionsResponse, error)
+	// Eliminar un archivo de la red y liberar sus chunks en los nodos.
+	DeleteFile(context.Context, *DeleteFileRequest) (*DeleteFileResponse, error)
+	// Reportar los chunks que almacena el nodo para reconciliarlos con el mapa del tracker.
+	ReportInventory(context.Context, *InventoryRequest) (*InventoryResponse, error)
+	// Reportar chunks corruptos detectados por el scrubber del nodo.
+	ReportCorruptChunks(context.Context, *CorruptChunksRequest) (*CorruptChunksResponse, error)
+	// Publicar el manifiesto firmado de un archivo recién subido.
+	PublishManifest(context.Context, *PublishManifestRequest) (*PublishManifestResponse, error)
+	// Consultar o cambiar los permisos de un archivo (solo su dueño los puede cambiar).
+	UpdateFileAcl(context.Context, *AclRequest) (*AclResponse, error)
+	// Crear un namespace o cambiar su cuota y sus permisos por defecto (solo su dueño).
+	UpdateNamespace(context.Context, *NamespaceRequest) (*NamespaceResponse, error)
+	// Listar los namespaces con su uso.
+	ListNamespaces(context.Context, *ListNamespacesRequest) (*ListNamespacesResponse, error)
+	// Consultar el espacio y el ancho de banda que usa un nodo, y sus cuotas.
+	GetUsage(context.Context, *UsageRequest) (*UsageResponse, error)
+	// Listar los archivos de un namespace, opcionalmente los que empiezan con un prefijo.
+	ListFiles(context.Context, *ListFilesRequest) (*ListFilesResponse, error)
+	// Buscar archivos de un namespace por subcadena o patrón glob.
+	SearchFiles(context.Context, *SearchFilesRequest) (*ListFilesResponse, error)
 	mustEmbedUnimplementedTrackerServiceServer()
 }
 
@@ -125,6 +320,45 @@ func (UnimplementedTrackerServiceServer) GetFileNodes(context.Context, *FileRequ
 func (UnimplementedTrackerServiceServer) PutFile(context.Context, *PutRequest) (*PutResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method PutFile not implemented")
 }
+func (UnimplementedTrackerServiceServer) Heartbeat(context.Context, *HeartbeatRequest) (*HeartbeatResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Heartbeat not implemented")
+}
+func (UnimplementedTrackerServiceServer) GetDomainReport(context.Context, *DomainReportRequest) (*DomainReportResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetDomainReport not implemented")
+}
+func (UnimplementedTrackerServiceServer) ListVersions(context.Context, *FileRequest) (*VersionsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListVersions not implemented")
+}
+func (UnimplementedTrackerServiceServer) DeleteFile(context.Context, *DeleteFileRequest) (*DeleteFileResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteFile not implemented")
+}
+func (UnimplementedTrackerServiceServer) ReportInventory(context.Context, *InventoryRequest) (*InventoryResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ReportInventory not implemented")
+}
+func (UnimplementedTrackerServiceServer) ReportCorruptChunks(context.Context, *CorruptChunksRequest) (*CorruptChunksResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ReportCorruptChunks not implemented")
+}
+func (UnimplementedTrackerServiceServer) PublishManifest(context.Context, *PublishManifestRequest) (*PublishManifestResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PublishManifest not implemented")
+}
+func (UnimplementedTrackerServiceServer) UpdateFileAcl(context.Context, *AclRequest) (*AclResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateFileAcl not implemented")
+}
+func (UnimplementedTrackerServiceServer) UpdateNamespace(context.Context, *NamespaceRequest) (*NamespaceResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateNamespace not implemented")
+}
+func (UnimplementedTrackerServiceServer) ListNamespaces(context.Context, *ListNamespacesRequest) (*ListNamespacesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListNamespaces not implemented")
+}
+func (UnimplementedTrackerServiceServer) GetUsage(context.Context, *UsageRequest) (*UsageResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetUsage not implemented")
+}
+func (UnimplementedTrackerServiceServer) ListFiles(context.Context, *ListFilesRequest) (*ListFilesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListFiles not implemented")
+}
+func (UnimplementedTrackerServiceServer) SearchFiles(context.Context, *SearchFilesRequest) (*ListFilesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SearchFiles not implemented")
+}
 func (UnimplementedTrackerServiceServer) mustEmbedUnimplementedTrackerServiceServer() {}
 func (UnimplementedTrackerServiceServer) testEmbeddedByValue()                        {}
 
@@ -218,6 +452,240 @@ func _TrackerService_PutFile_Handler(srv interface{}, ctx context.Context, dec f
 	return interceptor(ctx, in, info, handler)
 }
 
+func _TrackerService_Heartbeat_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(HeartbeatRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TrackerServiceServer).Heartbeat(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TrackerService_Heartbeat_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TrackerServiceServer).Heartbeat(ctx, req.(*HeartbeatRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TrackerService_GetDomainReport_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DomainReportRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TrackerServiceServer).GetDomainReport(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TrackerService_GetDomainReport_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TrackerServiceServer).GetDomainReport(ctx, req.(*DomainReportRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TrackerService_ListVersions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(FileRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TrackerServiceServer).ListVersions(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TrackerService_ListVersions_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TrackerServiceServer).ListVersions(ctx, req.(*FileRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TrackerService_DeleteFile_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteFileRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TrackerServiceServer).DeleteFile(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TrackerService_DeleteFile_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TrackerServiceServer).DeleteFile(ctx, req.(*DeleteFileRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TrackerService_ReportInventory_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(InventoryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TrackerServiceServer).ReportInventory(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TrackerService_ReportInventory_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TrackerServiceServer).ReportInventory(ctx, req.(*InventoryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TrackerService_ReportCorruptChunks_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CorruptChunksRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TrackerServiceServer).ReportCorruptChunks(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TrackerService_ReportCorruptChunks_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TrackerServiceServer).ReportCorruptChunks(ctx, req.(*CorruptChunksRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TrackerService_PublishManifest_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PublishManifestRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TrackerServiceServer).PublishManifest(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TrackerService_PublishManifest_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TrackerServiceServer).PublishManifest(ctx, req.(*PublishManifestRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TrackerService_UpdateFileAcl_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AclRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TrackerServiceServer).UpdateFileAcl(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TrackerService_UpdateFileAcl_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TrackerServiceServer).UpdateFileAcl(ctx, req.(*AclRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TrackerService_UpdateNamespace_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(NamespaceRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TrackerServiceServer).UpdateNamespace(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TrackerService_UpdateNamespace_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TrackerServiceServer).UpdateNamespace(ctx, req.(*NamespaceRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TrackerService_ListNamespaces_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListNamespacesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TrackerServiceServer).ListNamespaces(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TrackerService_ListNamespaces_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TrackerServiceServer).ListNamespaces(ctx, req.(*ListNamespacesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TrackerService_GetUsage_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UsageRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TrackerServiceServer).GetUsage(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TrackerService_GetUsage_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TrackerServiceServer).GetUsage(ctx, req.(*UsageRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TrackerService_ListFiles_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListFilesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TrackerServiceServer).ListFiles(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TrackerService_ListFiles_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TrackerServiceServer).ListFiles(ctx, req.(*ListFilesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TrackerService_SearchFiles_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SearchFilesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TrackerServiceServer).SearchFiles(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TrackerService_SearchFiles_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TrackerServiceServer).SearchFiles(ctx, req.(*SearchFilesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // TrackerService_ServiceDesc is the grpc.ServiceDesc for TrackerService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -241,14 +709,70 @@ var TrackerService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "PutFile",
 			Handler:    _TrackerService_PutFile_Handler,
 		},
+		{
+			MethodName: "Heartbeat",
+			Handler:    _TrackerService_Heartbeat_Handler,
+		},
+		{
+			MethodName: "GetDomainReport",
+			Handler:    _TrackerService_GetDomainReport_Handler,
+		},
+		{
+			MethodName: "ListVersions",
+			Handler:    _TrackerService_ListVersions_Handler,
+		},
+		{
+			MethodName: "DeleteFile",
+			Handler:    _TrackerService_DeleteFile_Handler,
+		},
+		{
+			MethodName: "ReportInventory",
+			Handler:    _TrackerService_ReportInventory_Handler,
+		},
+		{
+			MethodName: "ReportCorruptChunks",
+			Handler:    _TrackerService_ReportCorruptChunks_Handler,
+		},
+		{
+			MethodName: "PublishManifest",
+			Handler:    _TrackerService_PublishManifest_Handler,
+		},
+		{
+			MethodName: "UpdateFileAcl",
+			Handler:    _TrackerService_UpdateFileAcl_Handler,
+		},
+		{
+			MethodName: "UpdateNamespace",
+			Handler:    _TrackerService_UpdateNamespace_Handler,
+		},
+		{
+			MethodName: "ListNamespaces",
+			Handler:    _TrackerService_ListNamespaces_Handler,
+		},
+		{
+			MethodName: "GetUsage",
+			Handler:    _TrackerService_GetUsage_Handler,
+		},
+		{
+			MethodName: "ListFiles",
+			Handler:    _TrackerService_ListFiles_Handler,
+		},
+		{
+			MethodName: "SearchFiles",
+			Handler:    _TrackerService_SearchFiles_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "proto/peer.proto",
 }
 
 const (
-	NodeService_RequestChunk_FullMethodName = "/peer.NodeService/RequestChunk"
-	NodeService_StoreChunk_FullMethodName   = "/peer.NodeService/StoreChunk"
+	NodeService_RequestChunk_FullMethodName   = "/peer.NodeService/RequestChunk"
+	NodeService_StoreChunk_FullMethodName     = "/peer.NodeService/StoreChunk"
+	NodeService_ReplicateChunk_FullMethodName = "/peer.NodeService/ReplicateChunk"
+	NodeService_DeleteChunk_FullMethodName    = "/peer.NodeService/DeleteChunk"
+	NodeService_Challenge_FullMethodName      = "/peer.NodeService/Challenge"
+	NodeService_RebuildShards_FullMethodName  = "/peer.NodeService/RebuildShards"
 )
 
 // NodeServiceClient is the client API for NodeService service.
@@ -261,6 +785,14 @@ type NodeServiceClient interface {
 	RequestChunk(ctx context.Context, in *ChunkRequest, opts ...grpc.CallOption) (*ChunkResponse, error)
 	// Solicitud para almacenar un chunk
 	StoreChunk(ctx context.Context, in *StoreChunkRequest, opts ...grpc.CallOption) (*StoreChunkResponse, error)
+	// Copiar un chunk almacenado hacia otros nodos (re-replicación ordenada por el tracker).
+	ReplicateChunk(ctx context.Context, in *ReplicateChunkRequest, opts ...grpc.CallOption) (*ReplicateChunkResponse, error)
+	// Borrar un chunk almacenado (recolección de basura ordenada por el tracker).
+	DeleteChunk(ctx context.Context, in *DeleteChunkRequest, opts ...grpc.CallOption) (*DeleteChunkResponse, error)
+	// Demostrar que el nodo conserva un chunk respondiendo un desafío del tracker.
+	Challenge(ctx context.Context, in *ChallengeRequest, opts ...grpc.CallOption) (*ChallengeResponse, error)
+	// Reconstruir los shards perdidos de una franja y guardarlos en otros nodos (reparación ordenada por el tracker).
+	RebuildShards(ctx context.Context, in *RebuildShardsRequest, opts ...grpc.CallOption) (*RebuildShardsResponse, error)
 }
 
 type nodeServiceClient struct {
@@ -291,6 +823,46 @@ func (c *nodeServiceClient) StoreChunk(ctx context.Context, in *StoreChunkReques
 	return out, nil
 }
 
+func (c *nodeServiceClient) ReplicateChunk(ctx context.Context, in *ReplicateChunkRequest, opts ...grpc.CallOption) (*ReplicateChunkResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ReplicateChunkResponse)
+	err := c.cc.Invoke(ctx, NodeService_ReplicateChunk_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *nodeServiceClient) DeleteChunk(ctx context.Context, in *DeleteChunkRequest, opts ...grpc.CallOption) (*DeleteChunkResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DeleteChunkResponse)
+	err := c.cc.Invoke(ctx, NodeService_DeleteChunk_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *nodeServiceClient) Challenge(ctx context.Context, in *ChallengeRequest, opts ...grpc.CallOption) (*ChallengeResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ChallengeResponse)
+	err := c.cc.Invoke(ctx, NodeService_Challenge_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *nodeServiceClient) RebuildShards(ctx context.Context, in *RebuildShardsRequest, opts ...grpc.CallOption) (*RebuildShardsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RebuildShardsResponse)
+	err := c.cc.Invoke(ctx, NodeService_RebuildShards_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // NodeServiceServer is the server API for NodeService service.
 // All implementations must embed UnimplementedNodeServiceServer
 // for forward compatibility.
@@ -301,6 +873,14 @@ type NodeServiceServer interface {
 	RequestChunk(context.Context, *ChunkRequest) (*ChunkResponse, error)
 	// Solicitud para almacenar un chunk
 	StoreChunk(context.Context, *StoreChunkRequest) (*StoreChunkResponse, error)
+	// Copiar un chunk almacenado hacia otros nodos (re-replicación ordenada por el tracker).
+	ReplicateChunk(context.Context, *ReplicateChunkRequest) (*ReplicateChunkResponse, error)
+	// Borrar un chunk almacenado (recolección de basura ordenada por el tracker).
+	DeleteChunk(context.Context, *DeleteChunkRequest) (*DeleteChunkResponse, error)
+	// Demostrar que el nodo conserva un chunk respondiendo un desafío del tracker.
+	Challenge(context.Context, *ChallengeRequest) (*ChallengeResponse, error)
+	// Reconstruir los shards perdidos de una franja y guardarlos en otros nodos (reparación ordenada por el tracker).
+	RebuildShards(context.Context, *RebuildShardsRequest) (*RebuildShardsResponse, error)
 	mustEmbedUnimplementedNodeServiceServer()
 }
 
@@ -317,6 +897,18 @@ func (UnimplementedNodeServiceServer) RequestChunk(context.Context, *ChunkReques
 func (UnimplementedNodeServiceServer) StoreChunk(context.Context, *StoreChunkRequest) (*StoreChunkResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method StoreChunk not implemented")
 }
+func (UnimplementedNodeServiceServer) ReplicateChunk(context.Context, *ReplicateChunkRequest) (*ReplicateChunkResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ReplicateChunk not implemented")
+}
+func (UnimplementedNodeServiceServer) DeleteChunk(context.Context, *DeleteChunkRequest) (*DeleteChunkResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteChunk not implemented")
+}
+func (UnimplementedNodeServiceServer) Challenge(context.Context, *ChallengeRequest) (*ChallengeResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Challenge not implemented")
+}
+func (UnimplementedNodeServiceServer) RebuildShards(context.Context, *RebuildShardsRequest) (*RebuildShardsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RebuildShards not implemented")
+}
 func (UnimplementedNodeServiceServer) mustEmbedUnimplementedNodeServiceServer() {}
 func (UnimplementedNodeServiceServer) testEmbeddedByValue()                     {}
 
@@ -374,6 +966,78 @@ func _NodeService_StoreChunk_Handler(srv interface{}, ctx context.Context, dec f
 	return interceptor(ctx, in, info, handler)
 }
 
+func _NodeService_ReplicateChunk_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReplicateChunkRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NodeServiceServer).ReplicateChunk(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: NodeService_ReplicateChunk_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NodeServiceServer).ReplicateChunk(ctx, req.(*ReplicateChunkRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _NodeService_DeleteChunk_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteChunkRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NodeServiceServer).DeleteChunk(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: NodeService_DeleteChunk_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NodeServiceServer).DeleteChunk(ctx, req.(*DeleteChunkRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _NodeService_Challenge_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ChallengeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NodeServiceServer).Challenge(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: NodeService_Challenge_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NodeServiceServer).Challenge(ctx, req.(*ChallengeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _NodeService_RebuildShards_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RebuildShardsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NodeServiceServer).RebuildShards(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: NodeService_RebuildShards_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NodeServiceServer).RebuildShards(ctx, req.(*RebuildShardsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // NodeService_ServiceDesc is the grpc.ServiceDesc for NodeService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -389,6 +1053,22 @@ var NodeService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "StoreChunk",
 			Handler:    _NodeService_StoreChunk_Handler,
 		},
+		{
+			MethodName: "ReplicateChunk",
+			Handler:    _NodeService_ReplicateChunk_Handler,
+		},
+		{
+			MethodName: "DeleteChunk",
+			Handler:    _NodeService_DeleteChunk_Handler,
+		},
+		{
+			MethodName: "Challenge",
+			Handler:    _NodeService_Challenge_Handler,
+		},
+		{
+			MethodName: "RebuildShards",
+			Handler:    _NodeService_RebuildShards_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "proto/peer.proto",
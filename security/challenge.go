@@ -0,0 +1,120 @@
+package security
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/binary"
+	"errors"
+	"fmt"
+
+	pb "P2P_BitTorrent/pb"
+)
+
+// Tamaño de los bloques en que se divide un chunk para los desafíos de almacenamiento.
+const ChallengeBlockSize = 4096
+
+// Árbol de Merkle sobre los bloques de un chunk. Quien sube el archivo firma su raíz en el
+// manifiesto; para responder un desafío el nodo debe devolver el bloque pedido con su prueba,
+// lo que exige conservar el chunk completo. Las hojas llevan la posición del bloque y un
+// prefijo distinto al de las hojas de chunks, para que una prueba no sirva en otro árbol.
+
+// ChunkBlocks devuelve la cantidad de bloques de un chunk de size bytes; un chunk vacío
+// tiene un bloque vacío.
+func ChunkBlocks(size int64) int64 {
+	if size <= 0 {
+		return 1
+	}
+	return (size + ChallengeBlockSize - 1) / ChallengeBlockSize
+}
+
+// blockLeaf calcula el hash de la hoja de un bloque.
+func blockLeaf(block int64, data []byte) []byte {
+	h := sha256.New()
+	h.Write([]byte{2})
+	binary.Write(h, binary.BigEndian, block)
+	h.Write(data)
+	return h.Sum(nil)
+}
+
+// blockNode calcula el hash de un nodo interno a partir de sus hijos.
+func blockNode(left, right []byte) []byte {
+	h := sha256.New()
+	h.Write([]byte{3})
+	h.Write(left)
+	h.Write(right)
+	return h.Sum(nil)
+}
+
+// DigestChunk devuelve el resumen de un chunk que se publica en el manifiesto.
+func DigestChunk(data []byte) *pb.ChunkDigest {
+	root, _, _ := ProveBlock(data, 0)
+	return &pb.ChunkDigest{BlockRoot: root, Size: int64(len(data))}
+}
+
+// ProveBlock devuelve la raíz del árbol de bloques de un chunk, el contenido del bloque
+// indicado y su prueba. Si un nivel tiene una cantidad impar de nodos, el último sube sin
+// cambios.
+func ProveBlock(data []byte, block int64) ([]byte, []byte, []*pb.MerkleStep) {
+	blocks := ChunkBlocks(int64(len(data)))
+	if block < 0 || block >= blocks {
+		return nil, nil, nil
+	}
+
+	level := make([][]byte, blocks)
+	for i := range level {
+		start := min(int64(i)*ChallengeBlockSize, int64(len(data)))
+		end := min(start+ChallengeBlockSize, int64(len(data)))
+		level[i] = blockLeaf(int64(i), data[start:end])
+	}
+
+	var proof []*pb.MerkleStep
+	for pos := block; len(level) > 1; pos /= 2 {
+		switch {
+		case pos%2 == 1:
+			proof = append(proof, &pb.MerkleStep{Hash: level[pos-1], Left: true})
+		case pos+1 < int64(len(level)):
+			proof = append(proof, &pb.MerkleStep{Hash: level[pos+1]})
+		}
+
+		var next [][]byte
+		for i := 0; i < len(level); i += 2 {
+			if i+1 < len(level) {
+				next = append(next, blockNode(level[i], level[i+1]))
+			} else {
+				next = append(next, level[i])
+			}
+		}
+		level = next
+	}
+
+	start := block * ChallengeBlockSize
+	end := min(start+ChallengeBlockSize, int64(len(data)))
+	return level[0], data[min(start, end):end], proof
+}
+
+// VerifyBlock comprueba que data sea el bloque indicado del chunk con el resumen digest.
+func VerifyBlock(digest *pb.ChunkDigest, block int64, data []byte, proof []*pb.MerkleStep) error {
+	if digest == nil {
+		return errors.New("no se conoce el resumen del chunk")
+	}
+	if block < 0 || block >= ChunkBlocks(digest.Size) {
+		return fmt.Errorf("el bloque %d no existe en un chunk de %d bytes", block, digest.Size)
+	}
+	expected := min(digest.Size-block*ChallengeBlockSize, ChallengeBlockSize)
+	if int64(len(data)) != max(expected, 0) {
+		return fmt.Errorf("el bloque %d mide %d bytes y debería medir %d", block, len(data), expected)
+	}
+
+	hash := blockLeaf(block, data)
+	for _, step := range proof {
+		if step.Left {
+			hash = blockNode(step.Hash, hash)
+		} else {
+			hash = blockNode(hash, step.Hash)
+		}
+	}
+	if !bytes.Equal(hash, digest.BlockRoot) {
+		return fmt.Errorf("la prueba del bloque %d no coincide con la raíz publicada", block)
+	}
+	return nil
+}
@@ -0,0 +1,111 @@
+package security
+
+import (
+	"fmt"
+	"path"
+	"sort"
+	"strings"
+
+	pb "P2P_BitTorrent/pb"
+)
+
+// CheckCollection verifica que los archivos de una colección tengan rutas relativas que no
+// salgan de la carpeta donde se descarga, sin repetirse, y que cubran el contenido de la
+// colección en orden y sin huecos. Un manifiesto sin archivos no es una colección y es válido.
+func CheckCollection(manifest *pb.Manifest) error {
+	if len(manifest.Entries) == 0 {
+		return nil
+	}
+
+	paths := make(map[string]bool, len(manifest.Entries))
+	var offset int64
+	for _, entry := range manifest.Entries {
+		p := entry.Path
+		if p == "" || p == "." || path.IsAbs(p) || path.Clean(p) != p || p == ".." || strings.HasPrefix(p, "../") || strings.Contains(p, "\\") {
+			return fmt.Errorf("ruta inválida en la colección: %q", p)
+		}
+		if paths[p] {
+			return fmt.Errorf("ruta repetida en la colección: %s", p)
+		}
+		paths[p] = true
+		if entry.Offset != offset || entry.Size < 0 {
+			return fmt.Errorf("el archivo %s no empieza donde termina el anterior", p)
+		}
+		offset += entry.Size
+	}
+
+	// Un archivo no puede ser también la carpeta de otro
+	for p := range paths {
+		for dir := path.Dir(p); dir != "."; dir = path.Dir(dir) {
+			if paths[dir] {
+				return fmt.Errorf("la ruta %s de la colección es un archivo y una carpeta a la vez", dir)
+			}
+		}
+	}
+
+	// Con cifrado el manifiesto declara el tamaño de los chunks cifrados, no el del contenido
+	if !manifest.Encrypted && offset != manifest.FileSize {
+		return fmt.Errorf("los archivos de la colección suman %d bytes y el manifiesto declara %d", offset, manifest.FileSize)
+	}
+	return nil
+}
+
+// SelectEntries devuelve los archivos de una colección que coinciden con alguna de las
+// selecciones: una ruta exacta, una carpeta (todo lo que contiene) o un patrón glob. Sin
+// selecciones devuelve todos. Es un error que una selección no coincida con ningún archivo.
+func SelectEntries(manifest *pb.Manifest, selections []string) ([]*pb.CollectionEntry, error) {
+	if len(selections) == 0 {
+		return manifest.Entries, nil
+	}
+
+	var selected []*pb.CollectionEntry
+	matched := make(map[string]bool)
+	for _, entry := range manifest.Entries {
+		include := false
+		for _, selection := range selections {
+			selection = strings.TrimSuffix(selection, "/")
+			globMatch, err := path.Match(selection, entry.Path)
+			if err != nil {
+				return nil, fmt.Errorf("patrón inválido: %s", selection)
+			}
+			if entry.Path == selection || strings.HasPrefix(entry.Path, selection+"/") || globMatch {
+				include = true
+				matched[selection] = true
+			}
+		}
+		if include {
+			selected = append(selected, entry)
+		}
+	}
+	for _, selection := range selections {
+		if !matched[strings.TrimSuffix(selection, "/")] {
+			return nil, fmt.Errorf("ningún archivo de la colección coincide con %s", selection)
+		}
+	}
+	return selected, nil
+}
+
+// CollectionChunks devuelve, en orden, los índices de los chunks de datos que cubren los
+// archivos indicados de una colección.
+func CollectionChunks(manifest *pb.Manifest, entries []*pb.CollectionEntry) []int32 {
+	chunkSize := manifest.ChunkSize
+	if chunkSize <= 0 {
+		return nil
+	}
+	total := int32(len(manifest.ChunkHashes))
+	needed := make(map[int32]bool)
+	for _, entry := range entries {
+		start := int32(entry.Offset/chunkSize) + 1
+		end := int32((entry.Offset+max(entry.Size, 1)-1)/chunkSize) + 1
+		for index := start; index <= min(end, total); index++ {
+			needed[index] = true
+		}
+	}
+
+	indices := make([]int32, 0, len(needed))
+	for index := range needed {
+		indices = append(indices, index)
+	}
+	sort.Slice(indices, func(i, j int) bool { return indices[i] < indices[j] })
+	return indices
+}
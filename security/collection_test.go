@@ -0,0 +1,100 @@
+package security
+
+import (
+	"slices"
+	"testing"
+
+	pb "P2P_BitTorrent/pb"
+)
+
+// testCollection devuelve una colección de 45 bytes en chunks de 10 bytes.
+func testCollection() *pb.Manifest {
+	return &pb.Manifest{
+		FileSize:    45,
+		ChunkSize:   10,
+		ChunkHashes: make([][]byte, 5),
+		Entries: []*pb.CollectionEntry{
+			{Path: "a.txt", Offset: 0, Size: 5},
+			{Path: "docs/b.md", Offset: 5, Size: 20},
+			{Path: "docs/c.md", Offset: 25, Size: 5},
+			{Path: "img/d.png", Offset: 30, Size: 0},
+			{Path: "e.bin", Offset: 30, Size: 15},
+		},
+	}
+}
+
+func TestCheckCollection(t *testing.T) {
+	tests := []struct {
+		name    string
+		change  func(m *pb.Manifest)
+		wantErr bool
+	}{
+		{name: "colección válida", change: func(*pb.Manifest) {}},
+		{name: "no es una colección", change: func(m *pb.Manifest) { m.Entries = nil }},
+		{name: "cifrada con otro tamaño", change: func(m *pb.Manifest) { m.Encrypted, m.FileSize = true, 64 }},
+		{name: "ruta que sale de la carpeta", change: func(m *pb.Manifest) { m.Entries[0].Path = "../a.txt" }, wantErr: true},
+		{name: "ruta absoluta", change: func(m *pb.Manifest) { m.Entries[0].Path = "/a.txt" }, wantErr: true},
+		{name: "ruta sin normalizar", change: func(m *pb.Manifest) { m.Entries[1].Path = "docs/./b.md" }, wantErr: true},
+		{name: "ruta con barra invertida", change: func(m *pb.Manifest) { m.Entries[1].Path = `docs\b.md` }, wantErr: true},
+		{name: "ruta vacía", change: func(m *pb.Manifest) { m.Entries[0].Path = "" }, wantErr: true},
+		{name: "ruta repetida", change: func(m *pb.Manifest) { m.Entries[2].Path = "docs/b.md" }, wantErr: true},
+		{name: "archivo y carpeta a la vez", change: func(m *pb.Manifest) { m.Entries[0].Path = "docs" }, wantErr: true},
+		{name: "hueco entre archivos", change: func(m *pb.Manifest) { m.Entries[2].Offset = 26 }, wantErr: true},
+		{name: "tamaño negativo", change: func(m *pb.Manifest) { m.Entries[3].Size = -1 }, wantErr: true},
+		{name: "tamaño total distinto", change: func(m *pb.Manifest) { m.FileSize = 44 }, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			manifest := testCollection()
+			tt.change(manifest)
+			err := CheckCollection(manifest)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("CheckCollection: error %v, se esperaba error: %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestSelectEntries(t *testing.T) {
+	tests := []struct {
+		name       string
+		selections []string
+		wantPaths  []string
+		wantChunks []int32
+		wantErr    bool
+	}{
+		{name: "sin selecciones", wantPaths: []string{"a.txt", "docs/b.md", "docs/c.md", "img/d.png", "e.bin"}, wantChunks: []int32{1, 2, 3, 4, 5}},
+		{name: "ruta exacta", selections: []string{"a.txt"}, wantPaths: []string{"a.txt"}, wantChunks: []int32{1}},
+		{name: "carpeta", selections: []string{"docs"}, wantPaths: []string{"docs/b.md", "docs/c.md"}, wantChunks: []int32{1, 2, 3}},
+		{name: "carpeta con barra final", selections: []string{"docs/"}, wantPaths: []string{"docs/b.md", "docs/c.md"}, wantChunks: []int32{1, 2, 3}},
+		{name: "patrón que no cruza carpetas", selections: []string{"*.md"}, wantErr: true},
+		{name: "patrón dentro de una carpeta", selections: []string{"docs/c.*"}, wantPaths: []string{"docs/c.md"}, wantChunks: []int32{3}},
+		{name: "archivo vacío", selections: []string{"img/d.png"}, wantPaths: []string{"img/d.png"}, wantChunks: []int32{4}},
+		{name: "varias selecciones", selections: []string{"e.bin", "a.txt"}, wantPaths: []string{"a.txt", "e.bin"}, wantChunks: []int32{1, 4, 5}},
+		{name: "prefijo que no es carpeta", selections: []string{"doc"}, wantErr: true},
+		{name: "una selección sin coincidencias", selections: []string{"a.txt", "nada"}, wantErr: true},
+		{name: "patrón inválido", selections: []string{"["}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			manifest := testCollection()
+			entries, err := SelectEntries(manifest, tt.selections)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("SelectEntries: error %v, se esperaba error: %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			var paths []string
+			for _, entry := range entries {
+				paths = append(paths, entry.Path)
+			}
+			if !slices.Equal(paths, tt.wantPaths) {
+				t.Errorf("archivos %v, se esperaba %v", paths, tt.wantPaths)
+			}
+			if chunks := CollectionChunks(manifest, entries); !slices.Equal(chunks, tt.wantChunks) {
+				t.Errorf("chunks %v, se esperaba %v", chunks, tt.wantChunks)
+			}
+		})
+	}
+}
@@ -0,0 +1,197 @@
+package security
+
+import (
+	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"os"
+	"time"
+
+	pb "P2P_BitTorrent/pb"
+
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// Diferencia máxima aceptada entre el reloj del nodo que firma y el del tracker.
+const MaxClockSkew = 2 * time.Minute
+
+// Campo de las solicitudes al tracker que lleva la firma del nodo.
+const authField = "auth"
+
+// Identity es el par de claves Ed25519 con el que un nodo firma sus solicitudes al tracker.
+type Identity struct {
+	key ed25519.PrivateKey
+}
+
+// LoadOrCreateIdentity carga la clave del nodo desde path o, si no existe, genera una nueva y la guarda.
+func LoadOrCreateIdentity(path string) (*Identity, error) {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		_, key, err := ed25519.GenerateKey(rand.Reader)
+		if err != nil {
+			return nil, err
+		}
+		block := pem.EncodeToMemory(&pem.Block{Type: "ED25519 PRIVATE KEY", Bytes: key.Seed()})
+		if err := os.WriteFile(path, block, 0600); err != nil {
+			return nil, fmt.Errorf("no se pudo guardar la clave del nodo: %v", err)
+		}
+		return &Identity{key: key}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("no se pudo leer la clave del nodo: %v", err)
+	}
+
+	block, _ := pem.Decode(data)
+	if block == nil || len(block.Bytes) != ed25519.SeedSize {
+		return nil, fmt.Errorf("el archivo %s no contiene una clave Ed25519 válida", path)
+	}
+	return &Identity{key: ed25519.NewKeyFromSeed(block.Bytes)}, nil
+}
+
+// NewIdentity genera un par de claves que no se guarda en disco.
+func NewIdentity() (*Identity, error) {
+	_, key, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		return nil, err
+	}
+	return &Identity{key: key}, nil
+}
+
+// PublicKey devuelve la clave pública del nodo.
+func (id *Identity) PublicKey() ed25519.PublicKey {
+	return id.key.Public().(ed25519.PublicKey)
+}
+
+// Sign firma una solicitud que tenga el campo auth. Las solicitudes sin ese campo no se modifican.
+func (id *Identity) Sign(msg proto.Message) error {
+	field := authFieldOf(msg)
+	if field == nil {
+		return nil
+	}
+
+	auth := &pb.NodeAuth{PublicKey: id.PublicKey(), Timestamp: time.Now().UnixNano()}
+	msg.ProtoReflect().Set(field, protoreflect.ValueOfMessage(auth.ProtoReflect()))
+	payload, err := signedPayload(msg, field)
+	if err != nil {
+		return err
+	}
+	auth.Signature = ed25519.Sign(id.key, payload)
+	return nil
+}
+
+// UnaryClientInterceptor firma todas las solicitudes salientes que tengan el campo auth.
+func (id *Identity) UnaryClientInterceptor() grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		if msg, ok := req.(proto.Message); ok {
+			if err := id.Sign(msg); err != nil {
+				return fmt.Errorf("no se pudo firmar la solicitud: %v", err)
+			}
+		}
+		return invoker(ctx, method, req, reply, cc, opts...)
+	}
+}
+
+// VerifyRequest verifica la firma de una solicitud y la devuelve, con la clave pública que
+// la firmó y el momento en que se firmó.
+func VerifyRequest(msg proto.Message) (*pb.NodeAuth, error) {
+	field := authFieldOf(msg)
+	if field == nil || !msg.ProtoReflect().Has(field) {
+		return nil, errors.New("la solicitud no está firmada")
+	}
+	auth := msg.ProtoReflect().Get(field).Message().Interface().(*pb.NodeAuth)
+
+	if len(auth.PublicKey) != ed25519.PublicKeySize {
+		return nil, errors.New("clave pública inválida")
+	}
+	skew := time.Since(time.Unix(0, auth.Timestamp))
+	if skew > MaxClockSkew || skew < -MaxClockSkew {
+		return nil, errors.New("la firma de la solicitud está vencida")
+	}
+
+	payload, err := signedPayload(msg, field)
+	if err != nil {
+		return nil, err
+	}
+	if !ed25519.Verify(auth.PublicKey, payload, auth.Signature) {
+		return nil, errors.New("firma inválida")
+	}
+	return auth, nil
+}
+
+// authFieldOf devuelve el campo auth de una solicitud, o nil si no lo tiene.
+func authFieldOf(msg proto.Message) protoreflect.FieldDescriptor {
+	field := msg.ProtoReflect().Descriptor().Fields().ByName(authField)
+	if field == nil || field.Message() == nil || field.Message().FullName() != (&pb.NodeAuth{}).ProtoReflect().Descriptor().FullName() {
+		return nil
+	}
+	return field
+}
+
+// signedPayload serializa la solicitud sin la firma, precedida por el nombre de su tipo
+// para que la firma de un tipo de solicitud no sirva para otro.
+func signedPayload(msg proto.Message, field protoreflect.FieldDescriptor) ([]byte, error) {
+	clone := proto.Clone(msg)
+	auth := clone.ProtoReflect().Get(field).Message().Interface().(*pb.NodeAuth)
+	auth.Signature = nil
+
+	data, err := proto.MarshalOptions{Deterministic: true}.Marshal(clone)
+	if err != nil {
+		return nil, err
+	}
+	name := msg.ProtoReflect().Descriptor().FullName()
+	return append([]byte(string(name)+"\x00"), data...), nil
+}
+
+// SignManifest firma el manifiesto de un archivo con la clave del nodo.
+func (id *Identity) SignManifest(manifest *pb.Manifest) error {
+	manifest.PublisherKey = id.PublicKey()
+	payload, err := manifestPayload(manifest)
+	if err != nil {
+		return err
+	}
+	manifest.Signature = ed25519.Sign(id.key, payload)
+	return nil
+}
+
+// VerifyManifest verifica que el manifiesto esté firmado por la clave que declara.
+func VerifyManifest(manifest *pb.Manifest) error {
+	if len(manifest.PublisherKey) != ed25519.PublicKeySize {
+		return errors.New("clave pública del manifiesto inválida")
+	}
+	payload, err := manifestPayload(manifest)
+	if err != nil {
+		return err
+	}
+	if !ed25519.Verify(manifest.PublisherKey, payload, manifest.Signature) {
+		return errors.New("firma del manifiesto inválida")
+	}
+	return nil
+}
+
+// manifestPayload serializa el manifiesto sin la firma, precedido por el nombre de su tipo.
+func manifestPayload(manifest *pb.Manifest) ([]byte, error) {
+	return detachedPayload(manifest)
+}
+
+// detachedPayload serializa un mensaje que lleva su firma en el campo "signature", sin
+// ese campo y precedido por el nombre de su tipo.
+func detachedPayload(msg proto.Message) ([]byte, error) {
+	clone := proto.Clone(msg)
+	field := clone.ProtoReflect().Descriptor().Fields().ByName("signature")
+	if field == nil {
+		return nil, fmt.Errorf("el mensaje %s no lleva firma", clone.ProtoReflect().Descriptor().FullName())
+	}
+	clone.ProtoReflect().Clear(field)
+
+	data, err := proto.MarshalOptions{Deterministic: true}.Marshal(clone)
+	if err != nil {
+		return nil, err
+	}
+	name := clone.ProtoReflect().Descriptor().FullName()
+	return append([]byte(string(name)+"\x00"), data...), nil
+}
@@ -0,0 +1,103 @@
+package security
+
+import (
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/ecdh"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/sha512"
+	"errors"
+
+	pb "P2P_BitTorrent/pb"
+)
+
+// Etiqueta que separa la derivación de la clave de envoltura de otros usos del secreto X25519.
+const wrapLabel = "P2P_BitTorrent wrap v1"
+
+// ExchangeKey devuelve la clave X25519 del nodo, derivada de su clave Ed25519, con la que
+// recibe las claves de los archivos cifrados para él.
+func (id *Identity) ExchangeKey() *ecdh.PrivateKey {
+	// Misma conversión que usa Ed25519: los primeros 32 bytes del SHA-512 de la semilla
+	h := sha512.Sum512(id.key.Seed())
+	key, err := ecdh.X25519().NewPrivateKey(h[:32])
+	if err != nil {
+		panic(err) // Cualquier secuencia de 32 bytes es una clave X25519 válida
+	}
+	return key
+}
+
+// ExchangePublicKey devuelve la clave pública X25519 del nodo, la que comparte con quienes
+// quieran enviarle archivos cifrados.
+func (id *Identity) ExchangePublicKey() []byte {
+	return id.ExchangeKey().PublicKey().Bytes()
+}
+
+// WrapKey cifra la clave de un archivo para el destinatario con clave pública X25519
+// recipient, usando una clave efímera para que solo él la pueda recuperar.
+func WrapKey(fileKey, recipient []byte) (*pb.WrappedKey, error) {
+	recipientKey, err := ecdh.X25519().NewPublicKey(recipient)
+	if err != nil {
+		return nil, errors.New("clave pública X25519 del destinatario inválida")
+	}
+	ephemeral, err := ecdh.X25519().GenerateKey(rand.Reader)
+	if err != nil {
+		return nil, err
+	}
+	shared, err := ephemeral.ECDH(recipientKey)
+	if err != nil {
+		return nil, err
+	}
+
+	wrapped := &pb.WrappedKey{Recipient: recipient, EphemeralKey: ephemeral.PublicKey().Bytes()}
+	aead, err := wrapCipher(shared, wrapped)
+	if err != nil {
+		return nil, err
+	}
+	// La clave de envoltura es distinta en cada llamada, así que el nonce fijo no se repite
+	wrapped.Ciphertext = aead.Seal(nil, make([]byte, aead.NonceSize()), fileKey, nil)
+	return wrapped, nil
+}
+
+// UnwrapKey busca entre las claves envueltas la destinada al nodo y la descifra.
+func (id *Identity) UnwrapKey(wrappedKeys []*pb.WrappedKey) ([]byte, error) {
+	key := id.ExchangeKey()
+	for _, wrapped := range wrappedKeys {
+		if !bytes.Equal(wrapped.Recipient, key.PublicKey().Bytes()) {
+			continue
+		}
+		ephemeral, err := ecdh.X25519().NewPublicKey(wrapped.EphemeralKey)
+		if err != nil {
+			return nil, errors.New("clave efímera inválida")
+		}
+		shared, err := key.ECDH(ephemeral)
+		if err != nil {
+			return nil, err
+		}
+		aead, err := wrapCipher(shared, wrapped)
+		if err != nil {
+			return nil, err
+		}
+		fileKey, err := aead.Open(nil, make([]byte, aead.NonceSize()), wrapped.Ciphertext, nil)
+		if err != nil {
+			return nil, errors.New("no se pudo descifrar la clave del archivo")
+		}
+		return fileKey, nil
+	}
+	return nil, errors.New("el archivo no tiene una clave cifrada para este nodo")
+}
+
+// wrapCipher deriva la clave de envoltura del secreto compartido y de las dos claves públicas.
+func wrapCipher(shared []byte, wrapped *pb.WrappedKey) (cipher.AEAD, error) {
+	h := sha256.New()
+	h.Write([]byte(wrapLabel))
+	h.Write(shared)
+	h.Write(wrapped.EphemeralKey)
+	h.Write(wrapped.Recipient)
+	block, err := aes.NewCipher(h.Sum(nil))
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
@@ -0,0 +1,129 @@
+package security
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"os"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+// TrackerIdentity es el nombre común del certificado del tracker.
+const TrackerIdentity = "tracker"
+
+// Config guarda las rutas de los archivos de TLS. Sin certificado ni CA las conexiones van
+// sin cifrar; con CA el servidor exige certificado a los clientes (TLS mutuo).
+type Config struct {
+	CertFile string // Certificado propio en PEM.
+	KeyFile  string // Clave privada del certificado en PEM.
+	CAFile   string // Certificado de la CA que firma los certificados de la red.
+}
+
+// Credentials agrupa las credenciales de TLS ya cargadas. Un *Credentials nil representa
+// conexiones sin cifrar.
+type Credentials struct {
+	cert *tls.Certificate
+	pool *x509.CertPool
+}
+
+// Load carga los archivos de TLS indicados. Devuelve nil si no se configuró ninguno.
+func Load(cfg Config) (*Credentials, error) {
+	if cfg.CertFile == "" && cfg.KeyFile == "" && cfg.CAFile == "" {
+		return nil, nil
+	}
+
+	creds := &Credentials{}
+	if cfg.CertFile != "" || cfg.KeyFile != "" {
+		cert, err := tls.LoadX509KeyPair(cfg.CertFile, cfg.KeyFile)
+		if err != nil {
+			return nil, fmt.Errorf("no se pudo cargar el certificado: %v", err)
+		}
+		creds.cert = &cert
+	}
+	if cfg.CAFile != "" {
+		data, err := os.ReadFile(cfg.CAFile)
+		if err != nil {
+			return nil, fmt.Errorf("no se pudo leer la CA: %v", err)
+		}
+		creds.pool = x509.NewCertPool()
+		if !creds.pool.AppendCertsFromPEM(data) {
+			return nil, errors.New("el archivo de la CA no contiene certificados válidos")
+		}
+	}
+	return creds, nil
+}
+
+// ServerOptions devuelve las opciones para un servidor gRPC: las credenciales de TLS y un
+// interceptor que verifica que el node_id de cada solicitud coincida con el certificado del cliente.
+func (c *Credentials) ServerOptions() []grpc.ServerOption {
+	if c == nil {
+		return nil
+	}
+
+	config := &tls.Config{MinVersion: tls.VersionTLS12}
+	if c.cert != nil {
+		config.Certificates = []tls.Certificate{*c.cert}
+	}
+	if c.pool != nil {
+		config.ClientCAs = c.pool
+		config.ClientAuth = tls.RequireAndVerifyClientCert
+	}
+	return []grpc.ServerOption{
+		grpc.Creds(credentials.NewTLS(config)),
+		grpc.UnaryInterceptor(identityInterceptor),
+	}
+}
+
+// DialOption devuelve la opción para conectarse a otro nodo o al tracker.
+func (c *Credentials) DialOption() grpc.DialOption {
+	if c == nil {
+		return grpc.WithInsecure()
+	}
+
+	config := &tls.Config{MinVersion: tls.VersionTLS12, RootCAs: c.pool}
+	if c.cert != nil {
+		config.Certificates = []tls.Certificate{*c.cert}
+	}
+	return grpc.WithTransportCredentials(credentials.NewTLS(config))
+}
+
+// PeerIdentity devuelve la identidad del certificado verificado del cliente de una solicitud,
+// que es el nombre común (CN) del certificado.
+func PeerIdentity(ctx context.Context) (string, bool) {
+	p, ok := peer.FromContext(ctx)
+	if !ok {
+		return "", false
+	}
+	return CertificateIdentity(p)
+}
+
+// CertificateIdentity devuelve el nombre común (CN) del certificado verificado del otro
+// extremo de una conexión. Del lado del cliente se obtiene el peer con grpc.Peer.
+func CertificateIdentity(p *peer.Peer) (string, bool) {
+	if p == nil {
+		return "", false
+	}
+	info, ok := p.AuthInfo.(credentials.TLSInfo)
+	if !ok || len(info.State.VerifiedChains) == 0 || len(info.State.VerifiedChains[0]) == 0 {
+		return "", false
+	}
+	return info.State.VerifiedChains[0][0].Subject.CommonName, true
+}
+
+// identityInterceptor rechaza las solicitudes cuyo node_id no coincide con la identidad del
+// certificado del cliente, para que un nodo no pueda hacerse pasar por otro.
+func identityInterceptor(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	if r, ok := req.(interface{ GetNodeId() string }); ok {
+		if identity, verified := PeerIdentity(ctx); verified && r.GetNodeId() != identity {
+			return nil, status.Errorf(codes.PermissionDenied, "el certificado pertenece a %s, no a %s", identity, r.GetNodeId())
+		}
+	}
+	return handler(ctx, req)
+}
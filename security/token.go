@@ -0,0 +1,57 @@
+package security
+
+import (
+	"crypto/ed25519"
+	"errors"
+	"fmt"
+	"time"
+
+	pb "P2P_BitTorrent/pb"
+)
+
+// Alcances de los permisos de acceso.
+const (
+	ScopeRead      = "read"      // Pedir chunks del archivo a los nodos.
+	ScopeWrite     = "write"     // Guardar chunks del archivo en los nodos.
+	ScopeReplicate = "replicate" // Copiar o reconstruir chunks del archivo en otros nodos (reparación del tracker).
+	ScopeDelete    = "delete"    // Borrar chunks del archivo (recolección de basura del tracker).
+	ScopeChallenge = "challenge" // Responder desafíos de almacenamiento del tracker.
+)
+
+// SignToken firma un permiso de acceso con la clave del tracker.
+func (id *Identity) SignToken(token *pb.AccessToken) error {
+	payload, err := detachedPayload(token)
+	if err != nil {
+		return err
+	}
+	token.Signature = ed25519.Sign(id.key, payload)
+	return nil
+}
+
+// VerifyToken verifica que un permiso de acceso esté firmado por trackerKey, no haya
+// vencido, se haya entregado al nodo nodeID y tenga el alcance scope.
+func VerifyToken(token *pb.AccessToken, trackerKey ed25519.PublicKey, nodeID, scope string) error {
+	if token == nil {
+		return errors.New("falta el permiso de acceso del tracker")
+	}
+	if len(trackerKey) != ed25519.PublicKeySize {
+		return errors.New("no se conoce la clave del tracker")
+	}
+	payload, err := detachedPayload(token)
+	if err != nil {
+		return err
+	}
+	if !ed25519.Verify(trackerKey, payload, token.Signature) {
+		return errors.New("firma del permiso de acceso inválida")
+	}
+	if time.Now().Add(-MaxClockSkew).After(time.Unix(token.ExpiresAt, 0)) {
+		return errors.New("el permiso de acceso venció")
+	}
+	if token.NodeId != nodeID {
+		return errors.New("el permiso de acceso es de otro nodo")
+	}
+	if token.Scope != scope {
+		return fmt.Errorf("el permiso de acceso es para %q, no para %q", token.Scope, scope)
+	}
+	return nil
+}
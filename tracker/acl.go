@@ -0,0 +1,213 @@
+package tracker
+
+import (
+	"context"
+	"crypto/ed25519"
+	"fmt"
+	"log"
+	"time"
+
+	pb "P2P_BitTorrent/pb"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Duración de los permisos que el tracker entrega para leer o guardar los chunks de un archivo.
+const AccessTokenTTL = 10 * time.Minute
+
+// Visibilidad de un archivo en las solicitudes de cambio de permisos.
+const (
+	VisibilityPublic  = "public"  // Cualquier nodo puede descargar el archivo.
+	VisibilityPrivate = "private" // Solo el dueño y los lectores autorizados.
+)
+
+// fileACL son los permisos de un nombre de archivo, compartidos por todas sus versiones.
+// Los permisos se otorgan a claves públicas de nodos, que a diferencia de su ip:puerto no
+// cambian al reconectarse.
+type fileACL struct {
+	owner   string          // Clave del dueño: quien subió la primera versión
+	public  bool            // Si cualquier nodo puede descargar el archivo
+	readers map[string]bool // Claves que pueden descargar el archivo
+	writers map[string]bool // Claves que pueden subir versiones nuevas y eliminarlo
+}
+
+// newFileACL crea los permisos de un archivo nuevo con su dueño.
+func newFileACL(owner ed25519.PublicKey, public bool) *fileACL {
+	return &fileACL{
+		owner:   string(owner),
+		public:  public,
+		readers: make(map[string]bool),
+		writers: make(map[string]bool),
+	}
+}
+
+// canRead indica si la clave puede descargar el archivo; quien puede escribir también puede leer.
+func (a *fileACL) canRead(key ed25519.PublicKey) bool {
+	return a.public || a.readers[string(key)] || a.canWrite(key)
+}
+
+// canWrite indica si la clave puede subir versiones del archivo o eliminarlo.
+func (a *fileACL) canWrite(key ed25519.PublicKey) bool {
+	return string(key) == a.owner || a.writers[string(key)]
+}
+
+// proto convierte los permisos a la respuesta de UpdateFileAcl.
+func (a *fileACL) proto() *pb.AclResponse {
+	res := &pb.AclResponse{Owner: []byte(a.owner), Public: a.public}
+	for key := range a.readers {
+		res.Readers = append(res.Readers, []byte(key))
+	}
+	for key := range a.writers {
+		res.Writers = append(res.Writers, []byte(key))
+	}
+	return res
+}
+
+// aclChanges son los cambios de permisos de una solicitud de UpdateFileAcl o UpdateNamespace.
+type aclChanges interface {
+	GetVisibility() string
+	GetGrantRead() [][]byte
+	GetRevokeRead() [][]byte
+	GetGrantWrite() [][]byte
+	GetRevokeWrite() [][]byte
+}
+
+// hasChanges indica si la solicitud cambia algún permiso.
+func hasChanges(req aclChanges) bool {
+	return req.GetVisibility() != "" || len(req.GetGrantRead())+len(req.GetRevokeRead())+len(req.GetGrantWrite())+len(req.GetRevokeWrite()) > 0
+}
+
+// apply valida y aplica los cambios de permisos. Si alguno es inválido no se aplica ninguno.
+func (a *fileACL) apply(req aclChanges) error {
+	for _, keys := range [][][]byte{req.GetGrantRead(), req.GetRevokeRead(), req.GetGrantWrite(), req.GetRevokeWrite()} {
+		for _, key := range keys {
+			if len(key) != ed25519.PublicKeySize {
+				return status.Errorf(codes.InvalidArgument, "clave pública inválida: %x", key)
+			}
+		}
+	}
+
+	switch req.GetVisibility() {
+	case "":
+	case VisibilityPublic:
+		a.public = true
+	case VisibilityPrivate:
+		a.public = false
+	default:
+		return status.Errorf(codes.InvalidArgument, "visibilidad desconocida: %s", req.GetVisibility())
+	}
+	for _, key := range req.GetGrantRead() {
+		a.readers[string(key)] = true
+	}
+	for _, key := range req.GetRevokeRead() {
+		delete(a.readers, string(key))
+	}
+	for _, key := range req.GetGrantWrite() {
+		a.writers[string(key)] = true
+	}
+	for _, key := range req.GetRevokeWrite() {
+		delete(a.writers, string(key))
+	}
+	return nil
+}
+
+// checkAccess verifica que la clave pueda leer o escribir el archivo. Un nombre sin
+// versiones no tiene dueño y cualquiera lo puede subir.
+func (s *trackerServer) checkAccess(fileName fileKey, key ed25519.PublicKey, write bool) error {
+	acl, exists := s.acls[fileName]
+	if !exists {
+		return nil
+	}
+	if write && !acl.canWrite(key) {
+		return status.Errorf(codes.PermissionDenied, "no tiene permiso para modificar el archivo %s", fileName)
+	}
+	if !write && !acl.canRead(key) {
+		return status.Errorf(codes.PermissionDenied, "no tiene permiso para descargar el archivo %s", fileName)
+	}
+	return nil
+}
+
+// claimFile asigna el dueño de un nombre de archivo en su primera subida. El archivo recibe
+// los permisos por defecto de su namespace, y el dueño del namespace puede modificarlo.
+func (s *trackerServer) claimFile(fileName fileKey, owner ed25519.PublicKey, private bool) {
+	if _, exists := s.acls[fileName]; exists {
+		return
+	}
+
+	acl := newFileACL(owner, !private)
+	if ns, exists := s.namespaces[fileName.namespace]; exists {
+		acl.public = ns.defaults.public && !private
+		for key := range ns.defaults.readers {
+			acl.readers[key] = true
+		}
+		for key := range ns.defaults.writers {
+			acl.writers[key] = true
+		}
+		if ns.defaults.owner != acl.owner {
+			acl.writers[ns.defaults.owner] = true
+		}
+	}
+	s.acls[fileName] = acl
+	log.Printf("Archivo %s registrado con dueño %x (público: %v)", fileName, owner, acl.public)
+}
+
+// issueToken firma el permiso con el que un nodo pide (security.ScopeRead) o guarda
+// (security.ScopeWrite) los chunks de un archivo, o la orden con la que el tracker le pide
+// copiarlos, borrarlos o responder un desafío sobre ellos. Si se indican chunks, el permiso
+// solo vale para esos índices. Los nodos lo verifican con la clave del tracker, sin
+// consultarlo en cada solicitud.
+func (s *trackerServer) issueToken(fileID, nodeID, scope string, chunks ...int32) (*pb.AccessToken, error) {
+	token := &pb.AccessToken{
+		FileId:    fileID,
+		NodeId:    nodeID,
+		ExpiresAt: time.Now().Add(AccessTokenTTL).Unix(),
+		Scope:     scope,
+		Chunks:    chunks,
+	}
+	if err := s.identity.SignToken(token); err != nil {
+		return nil, status.Errorf(codes.Internal, "no se pudo firmar el permiso de acceso: %v", err)
+	}
+	return token, nil
+}
+
+// UpdateFileAcl aplica los cambios de permisos de un archivo y devuelve los permisos
+// resultantes. Solo el dueño puede cambiarlos; quien puede leer el archivo puede consultarlos.
+func (s *trackerServer) UpdateFileAcl(ctx context.Context, req *pb.AclRequest) (*pb.AclResponse, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	info, err := s.authenticate(ctx, req.NodeId, req)
+	if err != nil {
+		return nil, err
+	}
+	if info == nil {
+		return nil, status.Errorf(codes.PermissionDenied, "nodo %s no registrado en la red", req.NodeId)
+	}
+
+	key := fileKey{namespace: req.Namespace, name: req.FileName}
+	acl, exists := s.acls[key]
+	if !exists {
+		return nil, status.Errorf(codes.NotFound, "archivo %s no encontrado en la red", key)
+	}
+
+	if !hasChanges(req) {
+		if !acl.canRead(info.publicKey) {
+			return nil, status.Errorf(codes.PermissionDenied, "no tiene permiso para ver el archivo %s", key)
+		}
+		res := acl.proto()
+		res.Message = fmt.Sprintf("Permisos del archivo %s.", key)
+		return res, nil
+	}
+	if string(info.publicKey) != acl.owner {
+		return nil, status.Errorf(codes.PermissionDenied, "solo el dueño puede cambiar los permisos del archivo %s", key)
+	}
+	if err := acl.apply(req); err != nil {
+		return nil, err
+	}
+
+	log.Printf("Permisos del archivo %s cambiados por %s", key, req.NodeId)
+	res := acl.proto()
+	res.Message = fmt.Sprintf("Permisos del archivo %s actualizados.", key)
+	return res, nil
+}
@@ -0,0 +1,77 @@
+package tracker
+
+import (
+	"context"
+	"testing"
+
+	pb "P2P_BitTorrent/pb"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestFileACL(t *testing.T) {
+	tests := []struct {
+		name      string
+		private   bool
+		byOther   bool // Quien pide el cambio es el otro nodo y no el dueño.
+		change    func(other []byte) *pb.AclRequest
+		wantCode  codes.Code
+		wantRead  bool // Si el otro nodo puede descargar el archivo después del cambio.
+		wantWrite bool // Si puede subir una versión nueva.
+	}{
+		{name: "otro nodo consulta un archivo público", byOther: true, change: func([]byte) *pb.AclRequest { return &pb.AclRequest{} }, wantRead: true},
+		{name: "otro nodo consulta un archivo privado", private: true, byOther: true, change: func([]byte) *pb.AclRequest { return &pb.AclRequest{} }, wantCode: codes.PermissionDenied},
+		{name: "el dueño lo hace privado", change: func([]byte) *pb.AclRequest { return &pb.AclRequest{Visibility: VisibilityPrivate} }},
+		{name: "lector autorizado", private: true, change: func(other []byte) *pb.AclRequest { return &pb.AclRequest{GrantRead: [][]byte{other}} }, wantRead: true},
+		{name: "escritor autorizado", private: true, change: func(other []byte) *pb.AclRequest { return &pb.AclRequest{GrantWrite: [][]byte{other}} }, wantRead: true, wantWrite: true},
+		{name: "permiso otorgado y revocado", private: true, change: func(other []byte) *pb.AclRequest {
+			return &pb.AclRequest{GrantWrite: [][]byte{other}, RevokeWrite: [][]byte{other}}
+		}},
+		{name: "otro nodo se otorga permisos", private: true, byOther: true, change: func(other []byte) *pb.AclRequest { return &pb.AclRequest{GrantWrite: [][]byte{other}} }, wantCode: codes.PermissionDenied},
+		{name: "otro nodo lo hace público", private: true, byOther: true, change: func([]byte) *pb.AclRequest { return &pb.AclRequest{Visibility: VisibilityPublic} }, wantCode: codes.PermissionDenied},
+		{name: "clave inválida", private: true, change: func([]byte) *pb.AclRequest {
+			return &pb.AclRequest{Visibility: VisibilityPublic, GrantRead: [][]byte{[]byte("corta")}}
+		}, wantCode: codes.InvalidArgument},
+		{name: "visibilidad desconocida", change: func([]byte) *pb.AclRequest { return &pb.AclRequest{Visibility: "oculto"} }, wantCode: codes.InvalidArgument, wantRead: true},
+		{name: "archivo inexistente", change: func([]byte) *pb.AclRequest { return &pb.AclRequest{FileName: "otro"} }, wantCode: codes.NotFound, wantRead: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, nodes := newTestNetwork(t, Config{Replicas: 1}, 3)
+			owner, other := nodes[0], nodes[1]
+			if _, err := owner.join(s, &pb.JoinRequest{Action: "put", FileName: "archivo", FileSizeMb: 1, Private: tt.private}); err != nil {
+				t.Fatalf("put: %v", err)
+			}
+
+			changer := owner
+			if tt.byOther {
+				changer = other
+			}
+			req := tt.change(other.identity.PublicKey())
+			req.NodeId = changer.id
+			if req.FileName == "" {
+				req.FileName = "archivo"
+			}
+			if err := changer.identity.Sign(req); err != nil {
+				t.Fatalf("Sign: %v", err)
+			}
+			_, err := s.UpdateFileAcl(context.Background(), req)
+			if got := status.Code(err); got != tt.wantCode {
+				t.Fatalf("UpdateFileAcl: código %v, se esperaba %v (%v)", got, tt.wantCode, err)
+			}
+
+			_, err = other.join(s, &pb.JoinRequest{Action: "get", FileName: "archivo"})
+			if read := err == nil; read != tt.wantRead {
+				t.Errorf("el otro nodo puede descargar = %v, se esperaba %v (%v)", read, tt.wantRead, err)
+			}
+			_, err = other.join(s, &pb.JoinRequest{Action: "put", FileName: "archivo", FileSizeMb: 1})
+			if write := err == nil; write != tt.wantWrite {
+				t.Errorf("el otro nodo puede subir una versión = %v, se esperaba %v (%v)", write, tt.wantWrite, err)
+			}
+			if _, err := owner.join(s, &pb.JoinRequest{Action: "get", FileName: "archivo"}); err != nil {
+				t.Errorf("el dueño no puede descargar su archivo: %v", err)
+			}
+		})
+	}
+}
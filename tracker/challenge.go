@@ -0,0 +1,218 @@
+package tracker
+
+import (
+	"context"
+	"crypto/sha256"
+	"log"
+	mathrand "math/rand"
+	"sync"
+	"time"
+
+	pb "P2P_BitTorrent/pb"
+	"P2P_BitTorrent/security"
+
+	"google.golang.org/grpc"
+)
+
+// Parámetros de los desafíos de almacenamiento.
+const (
+	ChallengeInterval    = time.Minute     // Intervalo entre rondas de desafíos.
+	ChallengeSample      = 8               // Chunks desafiados en cada ronda.
+	challengeTimeout     = 5 * time.Second // Tiempo máximo para responder un desafío.
+	MaxChallengeFailures = 3               // Desafíos fallados seguidos para marcar un nodo como no confiable.
+	UnreliableCooldown   = time.Hour       // Tiempo tras el cual un nodo no confiable vuelve a recibir chunks.
+)
+
+// StartChallengeLoop desafía periódicamente a los nodos a demostrar que conservan los chunks
+// que el tracker les asignó.
+func (s *trackerServer) StartChallengeLoop(interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for range ticker.C {
+		s.challengeRound()
+	}
+}
+
+// challengeRound elige al azar algunos chunks de archivos ya subidos y desafía a sus nodos.
+// Solo se desafían los chunks cuyo resumen está publicado en el manifiesto del archivo.
+func (s *trackerServer) challengeRound() {
+	s.mu.Lock()
+	s.forgiveUnreliable()
+
+	// Solo se desafían archivos con más antigüedad que el período de gracia, para no
+	// penalizar a nodos que todavía están recibiendo los chunks
+	var keys []chunkKey
+	digests := make(map[chunkKey]*pb.ChunkDigest)
+	for _, file := range s.allVersions() {
+		if time.Since(file.createdAt) < s.orphanGrace || file.badDigests {
+			continue
+		}
+		stored := file.manifest.GetStoredChunks()
+		for _, chunk := range file.chunks {
+			if int(chunk.key.index) > len(stored) || len(s.fileChunks[chunk.key]) == 0 {
+				continue
+			}
+			keys = append(keys, chunk.key)
+			digests[chunk.key] = stored[chunk.key.index-1]
+		}
+	}
+	mathrand.Shuffle(len(keys), func(i, j int) { keys[i], keys[j] = keys[j], keys[i] })
+	if len(keys) > ChallengeSample {
+		keys = keys[:ChallengeSample]
+	}
+	holders := make(map[chunkKey][]string)
+	for _, key := range keys {
+		holders[key] = append([]string(nil), s.fileChunks[key]...)
+	}
+	s.mu.Unlock()
+
+	// Los desafíos se hacen sin el lock para no bloquear al tracker mientras se contacta a los nodos
+	failed := false
+	for _, key := range keys {
+		if !s.challengeChunk(key, digests[key], holders[key]) {
+			failed = true
+		}
+	}
+	if failed {
+		s.repairUnderReplicated()
+	}
+}
+
+// challengeChunk pide a todos los nodos de un chunk el mismo bloque, elegido al azar, y
+// verifica cada respuesta por separado contra el resumen del chunk publicado en el
+// manifiesto, de modo que un chunk con un único nodo también se verifica. Fallan los nodos
+// que no responden o cuya prueba no coincide. Si varios nodos devuelven el mismo bloque y
+// ninguno coincide con el manifiesto, el que está mal es el resumen publicado: no se penaliza
+// a los nodos y el archivo deja de desafiarse. Devuelve false si algún nodo falló.
+func (s *trackerServer) challengeChunk(key chunkKey, digest *pb.ChunkDigest, holders []string) bool {
+	block := mathrand.Int63n(security.ChunkBlocks(digest.Size))
+
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	var passed, failed []string
+	mismatches := make(map[string]string) // Hash del bloque devuelto por cada nodo cuya prueba no coincidió.
+	for _, node := range holders {
+		wg.Add(1)
+		go func(node string) {
+			defer wg.Done()
+			res, err := s.sendChallenge(node, key, digest, block)
+			mu.Lock()
+			defer mu.Unlock()
+			if err != nil {
+				log.Printf("El nodo %s falló el desafío del chunk %s: %v", node, key, err)
+				failed = append(failed, node)
+				if res != nil {
+					sum := sha256.Sum256(res.BlockData)
+					mismatches[node] = string(sum[:])
+				}
+				return
+			}
+			passed = append(passed, node)
+		}(node)
+	}
+	wg.Wait()
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if len(passed) == 0 && sameMismatch(failed, mismatches) {
+		if file := s.findFile(key.fileID); file != nil {
+			file.badDigests = true
+		}
+		log.Printf("Los %d nodos del chunk %s devolvieron el mismo bloque y no coincide con el manifiesto; se descarta el resumen publicado y no se penaliza a los nodos", len(failed), key)
+		return true
+	}
+	for _, node := range passed {
+		if info, exists := s.nodes[node]; exists {
+			info.failures = 0
+		}
+	}
+	for _, node := range failed {
+		s.challengeFailed(node, key)
+	}
+	return len(failed) == 0
+}
+
+// sameMismatch indica si al menos dos nodos respondieron el desafío con el mismo bloque y
+// todos fallaron solo porque la prueba no coincide con el manifiesto.
+func sameMismatch(failed []string, mismatches map[string]string) bool {
+	if len(failed) < 2 {
+		return false
+	}
+	for _, node := range failed {
+		sum, exists := mismatches[node]
+		if !exists || sum != mismatches[failed[0]] {
+			return false
+		}
+	}
+	return true
+}
+
+// forgiveUnreliable vuelve a aceptar a los nodos que llevan UnreliableCooldown marcados como
+// no confiables: recuperan la posibilidad de recibir chunks nuevos y sus fallos se reinician,
+// aunque los chunks cuyo desafío fallaron siguen sin recuperarse desde su inventario. Debe
+// llamarse con s.mu tomado.
+func (s *trackerServer) forgiveUnreliable() {
+	for nodeID, info := range s.nodes {
+		if info.unreliable && time.Since(info.unreliableAt) >= UnreliableCooldown {
+			info.unreliable = false
+			info.failures = 0
+			log.Printf("Nodo %s vuelve a ser confiable tras %v", nodeID, UnreliableCooldown)
+		}
+	}
+}
+
+// challengeFailed descarta la réplica de un chunk cuyo desafío falló y, si el nodo acumula
+// demasiados fallos, lo marca como no confiable y descarta todas sus réplicas para que se
+// vuelvan a copiar en otros nodos. El inventario del nodo ya no las vuelve a agregar, sino
+// que le pide borrarlas. Debe llamarse con s.mu tomado.
+func (s *trackerServer) challengeFailed(nodeID string, key chunkKey) {
+	info, exists := s.nodes[nodeID]
+	if !exists {
+		return
+	}
+	s.dropReplica(key, nodeID)
+	info.failedChunks[key] = true
+
+	info.failures++
+	if info.failures < MaxChallengeFailures || info.unreliable {
+		return
+	}
+	info.unreliable = true
+	info.unreliableAt = time.Now()
+	for key, holders := range s.fileChunks {
+		if contains(holders, nodeID) {
+			s.dropReplica(key, nodeID)
+		}
+	}
+	log.Printf("Nodo %s marcado como no confiable tras %d desafíos fallados; sus réplicas se reubicarán", nodeID, info.failures)
+}
+
+// sendChallenge pide a un nodo un bloque de un chunk, con una orden firmada para él, y
+// verifica la prueba que devuelve. Si el nodo respondió pero la prueba no coincide, devuelve
+// también la respuesta.
+func (s *trackerServer) sendChallenge(node string, key chunkKey, digest *pb.ChunkDigest, block int64) (*pb.ChallengeResponse, error) {
+	token, err := s.issueToken(key.fileID, node, security.ScopeChallenge)
+	if err != nil {
+		return nil, err
+	}
+
+	conn, err := grpc.Dial(node, s.credentials.DialOption())
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+
+	client := pb.NewNodeServiceClient(conn)
+	ctx, cancel := context.WithTimeout(context.Background(), challengeTimeout)
+	defer cancel()
+
+	res, err := client.Challenge(ctx, &pb.ChallengeRequest{ChunkId: key.String(), Block: block, Token: token})
+	if err != nil {
+		return nil, err
+	}
+	if err := security.VerifyBlock(digest, block, res.BlockData, res.Proof); err != nil {
+		return res, err
+	}
+	return res, nil
+}
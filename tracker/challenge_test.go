@@ -0,0 +1,125 @@
+package tracker
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	pb "P2P_BitTorrent/pb"
+	"P2P_BitTorrent/security"
+
+	"google.golang.org/grpc"
+)
+
+// fakeHolder es un nodo que responde los desafíos con el contenido que guarda.
+type fakeHolder struct {
+	pb.UnimplementedNodeServiceServer
+	data []byte
+}
+
+func (h *fakeHolder) Challenge(ctx context.Context, req *pb.ChallengeRequest) (*pb.ChallengeResponse, error) {
+	_, block, proof := security.ProveBlock(h.data, req.Block)
+	return &pb.ChallengeResponse{BlockData: block, Proof: proof}, nil
+}
+
+// startHolder levanta un nodo falso que guarda data y devuelve su dirección.
+func startHolder(t *testing.T, data []byte) string {
+	t.Helper()
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("Listen: %v", err)
+	}
+	server := grpc.NewServer()
+	pb.RegisterNodeServiceServer(server, &fakeHolder{data: data})
+	go server.Serve(lis)
+	t.Cleanup(server.Stop)
+	return lis.Addr().String()
+}
+
+func TestChallengeChunk(t *testing.T) {
+	chunk := make([]byte, 3*security.ChallengeBlockSize+100)
+	for i := range chunk {
+		chunk[i] = byte(i)
+	}
+	corrupted := append([]byte(nil), chunk...)
+	for i := range corrupted {
+		corrupted[i] ^= 0xff
+	}
+	bogus := security.DigestChunk([]byte("otro contenido"))
+	bogus.Size = int64(len(chunk))
+
+	tests := []struct {
+		name        string
+		holders     [][]byte // Contenido que guarda cada nodo.
+		digest      *pb.ChunkDigest
+		wantOK      bool
+		wantBad     bool   // El resumen del manifiesto se descarta.
+		wantDropped []bool // Nodos que pierden la réplica.
+	}{
+		{name: "todos correctos", holders: [][]byte{chunk, chunk, chunk}, digest: security.DigestChunk(chunk), wantOK: true, wantDropped: []bool{false, false, false}},
+		{name: "un nodo corrupto", holders: [][]byte{chunk, corrupted, chunk}, digest: security.DigestChunk(chunk), wantDropped: []bool{false, true, false}},
+		{name: "único nodo corrupto", holders: [][]byte{corrupted}, digest: security.DigestChunk(chunk), wantDropped: []bool{true}},
+		{name: "manifiesto falso", holders: [][]byte{chunk, chunk, chunk}, digest: bogus, wantOK: true, wantBad: true, wantDropped: []bool{false, false, false}},
+		{name: "manifiesto falso con nodos en desacuerdo", holders: [][]byte{chunk, corrupted}, digest: bogus, wantDropped: []bool{true, true}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := NewTrackerServer(Config{})
+			key := chunkKey{fileID: "archivo-1", index: 1}
+			file := &fileRecord{id: key.fileID, name: "archivo", chunks: []chunkRef{{key: key}}}
+			s.files[file.key()] = []*fileRecord{file}
+
+			var holders []string
+			for _, data := range tt.holders {
+				node := startHolder(t, data)
+				s.nodes[node] = &nodeInfo{chunks: 1, failedChunks: make(map[chunkKey]bool)}
+				holders = append(holders, node)
+			}
+			s.fileChunks[key] = append([]string(nil), holders...)
+
+			if got := s.challengeChunk(key, tt.digest, holders); got != tt.wantOK {
+				t.Errorf("challengeChunk = %v, se esperaba %v", got, tt.wantOK)
+			}
+			if file.badDigests != tt.wantBad {
+				t.Errorf("badDigests = %v, se esperaba %v", file.badDigests, tt.wantBad)
+			}
+			for i, node := range holders {
+				dropped := !contains(s.fileChunks[key], node)
+				if dropped != tt.wantDropped[i] {
+					t.Errorf("nodo %d: réplica descartada = %v, se esperaba %v", i, dropped, tt.wantDropped[i])
+				}
+				if s.nodes[node].failedChunks[key] != tt.wantDropped[i] {
+					t.Errorf("nodo %d: chunk marcado como fallado = %v, se esperaba %v", i, s.nodes[node].failedChunks[key], tt.wantDropped[i])
+				}
+			}
+		})
+	}
+}
+
+func TestUnreliableNodesAreForgiven(t *testing.T) {
+	s := NewTrackerServer(Config{})
+	key := chunkKey{fileID: "archivo-1", index: 1}
+	s.nodes["reciente"] = &nodeInfo{failedChunks: make(map[chunkKey]bool)}
+	s.nodes["antiguo"] = &nodeInfo{failedChunks: make(map[chunkKey]bool)}
+	for i := 0; i < MaxChallengeFailures; i++ {
+		s.challengeFailed("reciente", key)
+		s.challengeFailed("antiguo", key)
+	}
+	if !s.nodes["reciente"].unreliable || !s.nodes["antiguo"].unreliable {
+		t.Fatalf("los nodos no se marcaron como no confiables tras %d fallos", MaxChallengeFailures)
+	}
+	s.nodes["antiguo"].unreliableAt = time.Now().Add(-UnreliableCooldown)
+
+	s.forgiveUnreliable()
+	if !s.nodes["reciente"].unreliable {
+		t.Error("se perdonó a un nodo antes de UnreliableCooldown")
+	}
+	old := s.nodes["antiguo"]
+	if old.unreliable || old.failures != 0 {
+		t.Errorf("el nodo antiguo sigue no confiable (unreliable=%v, failures=%d)", old.unreliable, old.failures)
+	}
+	if !old.failedChunks[key] {
+		t.Error("el nodo perdonado puede volver a adoptar el chunk cuyo desafío falló")
+	}
+}
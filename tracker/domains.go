@@ -0,0 +1,159 @@
+package tracker
+
+import (
+	"context"
+	"log"
+	"net"
+	"sort"
+	"strings"
+
+	pb "P2P_BitTorrent/pb"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Niveles de dominio de falla, del más amplio al más específico.
+var domainLevels = []string{"zone", "rack", "host"}
+
+// domainKey devuelve el dominio de un nodo en el nivel indicado, incluyendo los niveles
+// más amplios para que dos racks con el mismo nombre en zonas distintas no se confundan.
+// Si el nodo no declaró el nivel se devuelve "": el nodo no aporta un dominio en ese nivel y
+// se reparte por los niveles siguientes. El host, por defecto, es la IP del node_id.
+func domainKey(nodeID string, labels map[string]string, level string) string {
+	var parts []string
+	for _, l := range domainLevels {
+		value := labels[l]
+		if value == "" && l == "host" {
+			if host, _, err := net.SplitHostPort(nodeID); err == nil {
+				value = host
+			}
+		}
+		if value == "" {
+			if l == level {
+				return ""
+			}
+			value = "?"
+		}
+		parts = append(parts, value)
+		if l == level {
+			break
+		}
+	}
+	return strings.Join(parts, "/")
+}
+
+// spreadAcrossDomains elige replicas nodos de ordered (en el orden de preferencia de la
+// política) priorizando, en cada paso, el primer nodo que agregue una zona nueva, luego un
+// rack nuevo y luego un host nuevo; un nodo sin etiqueta en un nivel no cuenta como dominio
+// nuevo en él. Si ninguno agrega un dominio nuevo se toma el siguiente.
+// Los dominios de placed (nodos que ya tienen el chunk) cuentan como ocupados.
+func spreadAcrossDomains(ordered []Candidate, replicas int, placed []Candidate) []string {
+	used := make(map[string]map[string]bool)
+	for _, level := range domainLevels {
+		used[level] = make(map[string]bool)
+		for _, c := range placed {
+			if key := domainKey(c.NodeID, c.Labels, level); key != "" {
+				used[level][key] = true
+			}
+		}
+	}
+	taken := make([]bool, len(ordered))
+
+	var selectedNodes []string
+	for len(selectedNodes) < replicas && len(selectedNodes) < len(ordered) {
+		pick := -1
+		for _, level := range domainLevels {
+			for i, c := range ordered {
+				key := domainKey(c.NodeID, c.Labels, level)
+				if !taken[i] && key != "" && !used[level][key] {
+					pick = i
+					break
+				}
+			}
+			if pick >= 0 {
+				break
+			}
+		}
+		if pick < 0 {
+			for i := range ordered {
+				if !taken[i] {
+					pick = i
+					break
+				}
+			}
+		}
+
+		taken[pick] = true
+		c := ordered[pick]
+		for _, level := range domainLevels {
+			if key := domainKey(c.NodeID, c.Labels, level); key != "" {
+				used[level][key] = true
+			}
+		}
+		selectedNodes = append(selectedNodes, c.NodeID)
+	}
+	return selectedNodes
+}
+
+// sharedDomains devuelve, para cada nivel, los grupos de nodos del chunk que comparten dominio.
+func (s *trackerServer) sharedDomains(chunkID string, holders []string) []*pb.SharedDomainChunk {
+	var shared []*pb.SharedDomainChunk
+	for _, level := range domainLevels {
+		groups := make(map[string][]string)
+		for _, nodeID := range holders {
+			var labels map[string]string
+			if info, exists := s.nodes[nodeID]; exists {
+				labels = info.labels
+			}
+			if key := domainKey(nodeID, labels, level); key != "" {
+				groups[key] = append(groups[key], nodeID)
+			}
+		}
+		var domains []string
+		for domain, nodes := range groups {
+			if len(nodes) > 1 {
+				domains = append(domains, domain)
+			}
+		}
+		sort.Strings(domains)
+		for _, domain := range domains {
+			shared = append(shared, &pb.SharedDomainChunk{ChunkId: chunkID, Level: level, Domain: domain, Nodes: groups[domain]})
+		}
+	}
+	return shared
+}
+
+// GetDomainReport lista los chunks cuyas réplicas comparten un dominio de falla. Solo lo
+// pueden pedir los nodos registrados, porque revela la ubicación de los chunks.
+func (s *trackerServer) GetDomainReport(ctx context.Context, req *pb.DomainReportRequest) (*pb.DomainReportResponse, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	info, err := s.authenticate(ctx, req.NodeId, req)
+	if err != nil {
+		return nil, err
+	}
+	if info == nil {
+		return nil, status.Errorf(codes.PermissionDenied, "nodo %s no registrado en la red", req.NodeId)
+	}
+
+	var keys []chunkKey
+	for key := range s.fileChunks {
+		keys = append(keys, key)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		if keys[i].fileID != keys[j].fileID {
+			return keys[i].fileID < keys[j].fileID
+		}
+		return keys[i].index < keys[j].index
+	})
+
+	res := &pb.DomainReportResponse{}
+	for _, key := range keys {
+		res.Chunks = append(res.Chunks, s.sharedDomains(key.String(), s.fileChunks[key])...)
+	}
+
+	log.Printf("Reporte de dominios de falla: %d entradas", len(res.Chunks))
+	return res, nil
+}
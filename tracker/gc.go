@@ -0,0 +1,183 @@
+package tracker
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	pb "P2P_BitTorrent/pb"
+	"P2P_BitTorrent/security"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Intervalo entre rondas del recolector de chunks de archivos eliminados.
+const GCInterval = 30 * time.Second
+
+// Tiempo que se conserva la marca de un archivo eliminado. Pasado ese tiempo se dejan de
+// reintentar los borrados pendientes en nodos que no volvieron a la red.
+const TombstoneTTL = 24 * time.Hour
+
+// tombstone marca una versión de un archivo como eliminada.
+type tombstone struct {
+	name      string    // Nombre del archivo.
+	version   int32     // Versión eliminada.
+	deletedAt time.Time // Momento de la eliminación.
+}
+
+// removeFile marca un archivo como eliminado, lo quita del mapa de chunks y agenda el
+// borrado de sus chunks en los nodos que los almacenan.
+func (s *trackerServer) removeFile(file *fileRecord) {
+	s.tombstones[file.id] = &tombstone{name: file.key().String(), version: file.version, deletedAt: time.Now()}
+
+	for _, chunk := range file.chunks {
+		for _, node := range s.fileChunks[chunk.key] {
+			if info, exists := s.nodes[node]; exists && info.chunks > 0 {
+				info.chunks--
+			}
+			s.garbage[node] = append(s.garbage[node], chunk.key)
+		}
+		delete(s.fileChunks, chunk.key)
+	}
+}
+
+// DeleteFile elimina una versión de un archivo, o todas si version es 0, y libera sus
+// chunks en segundo plano.
+func (s *trackerServer) DeleteFile(ctx context.Context, req *pb.DeleteFileRequest) (*pb.DeleteFileResponse, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	info, err := s.authenticate(ctx, req.NodeId, req)
+	if err != nil {
+		return nil, err
+	}
+	if info == nil {
+		return nil, status.Errorf(codes.PermissionDenied, "nodo %s no registrado en la red", req.NodeId)
+	}
+	key := fileKey{namespace: req.Namespace, name: req.FileName}
+	if err := s.checkAccess(key, info.publicKey, true); err != nil {
+		return nil, err
+	}
+
+	versions := s.files[key]
+	var kept []*fileRecord
+	deleted := 0
+	for _, file := range versions {
+		if req.Version != 0 && file.version != req.Version {
+			kept = append(kept, file)
+			continue
+		}
+		s.removeFile(file)
+		deleted++
+		log.Printf("Versión %d del archivo %s (id %s) eliminada", file.version, key, file.id)
+	}
+
+	if deleted == 0 {
+		return nil, status.Errorf(codes.NotFound, "archivo %s no encontrado en la red", key)
+	}
+	if len(kept) > 0 {
+		s.files[key] = kept
+	} else {
+		// Sin versiones el nombre queda libre para cualquiera
+		delete(s.files, key)
+		delete(s.acls, key)
+	}
+
+	// Liberar en segundo plano el espacio de los nodos
+	go s.collectGarbage()
+
+	return &pb.DeleteFileResponse{Message: fmt.Sprintf("%d versiones del archivo %s eliminadas.", deleted, key)}, nil
+}
+
+// StartGCLoop reintenta periódicamente los borrados de chunks pendientes.
+func (s *trackerServer) StartGCLoop(interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for range ticker.C {
+		s.collectGarbage()
+	}
+}
+
+// collectGarbage pide a cada nodo activo que borre sus chunks pendientes. Los borrados
+// que fallan, o los de nodos que no están en la red, se reintentan en la próxima ronda.
+func (s *trackerServer) collectGarbage() {
+	s.gcMu.Lock()
+	defer s.gcMu.Unlock()
+
+	s.mu.Lock()
+	s.expireTombstones()
+	pending := make(map[string][]chunkKey)
+	for node, keys := range s.garbage {
+		if _, active := s.nodes[node]; active {
+			pending[node] = append([]chunkKey(nil), keys...)
+		}
+	}
+	s.mu.Unlock()
+
+	// Los borrados se hacen sin el lock para no bloquear al tracker mientras se contacta a los nodos
+	for node, keys := range pending {
+		for _, key := range keys {
+			if err := s.requestDeletion(node, key); err != nil {
+				log.Printf("Error al borrar el chunk %s del nodo %s, se reintentará: %v", key, node, err)
+				break // El nodo probablemente no responde; se reintenta en la próxima ronda
+			}
+
+			s.mu.Lock()
+			s.garbage[node] = removeKey(s.garbage[node], key)
+			if len(s.garbage[node]) == 0 {
+				delete(s.garbage, node)
+			}
+			s.mu.Unlock()
+			log.Printf("Chunk %s borrado del nodo %s", key, node)
+		}
+	}
+}
+
+// expireTombstones descarta las marcas de archivos eliminados hace más de TombstoneTTL junto
+// con sus borrados pendientes.
+func (s *trackerServer) expireTombstones() {
+	for fileID, mark := range s.tombstones {
+		if time.Since(mark.deletedAt) <= TombstoneTTL {
+			continue
+		}
+		for node, keys := range s.garbage {
+			var remaining []chunkKey
+			for _, key := range keys {
+				if key.fileID != fileID {
+					remaining = append(remaining, key)
+				}
+			}
+			if len(remaining) > 0 {
+				s.garbage[node] = remaining
+			} else {
+				delete(s.garbage, node)
+			}
+		}
+		delete(s.tombstones, fileID)
+	}
+}
+
+// requestDeletion pide a un nodo que borre un chunk con una orden firmada por el tracker.
+func (s *trackerServer) requestDeletion(node string, key chunkKey) error {
+	token, err := s.issueToken(key.fileID, node, security.ScopeDelete)
+	if err != nil {
+		return err
+	}
+
+	conn, err := grpc.Dial(node, s.credentials.DialOption())
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	client := pb.NewNodeServiceClient(conn)
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	_, err = client.DeleteChunk(ctx, &pb.DeleteChunkRequest{ChunkId: key.String(), Token: token})
+	return err
+}
@@ -5,56 +5,195 @@ import (
 	"log"
 
 	pb "P2P_BitTorrent/pb"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
-// handlePut fragmenta el archivo y distribuye los chunks entre varios nodos.
-func (s *trackerServer) handlePut(fileName string, fileSizeMb int32) (*pb.JoinResponse, error) {
-	chunks := int(fileSizeMb) // Suponiendo 1 chunk por MB
+// handlePut fragmenta el archivo y distribuye los chunks entre varios nodos. Si ya existe
+// un archivo con el mismo nombre, la subida se guarda como una versión nueva.
+func (s *trackerServer) handlePut(file *fileRecord) (*pb.JoinResponse, error) {
+	if file.storageMode == StorageErasure {
+		return s.handlePutErasure(file)
+	}
+
+	fileName := file.key()
+	replicas := file.replicas
+	chunks := int(file.sizeMb) // Suponiendo 1 chunk por MB
 	chunkMap := make(map[string]*pb.ChunkInfo)
-	replicas := 3 // Definimos que queremos 3 réplicas por chunk, por ejemplo
 
 	// Distribuir los chunks según la disponibilidad de los nodos
 	for i := 0; i < chunks; i++ {
-		chunkID := fmt.Sprintf("%s-%d", fileName, i+1) // Ej. Shakira.mp3-1
+		key := chunkKey{fileID: file.id, index: int32(i + 1)}
+		chunkID := key.String() // Ej. 1b4e28ba-2fa1-41d2-883f-0016d3cca427-1
 
 		// Seleccionar nodos para replicar el chunk
-		selectedNodes := s.selectNodesForChunk(replicas)
+		selectedNodes := s.selectNodesForChunk(chunkID, replicas, nil)
+		if len(selectedNodes) < replicas {
+			log.Printf("Solo %d de %d réplicas asignadas para el chunk %s: no hay suficientes nodos con espacio", len(selectedNodes), replicas, chunkID)
+		}
 
 		// Asignar los nodos seleccionados al chunk
 		for _, targetNode := range selectedNodes {
-			s.fileChunks[chunkID] = append(s.fileChunks[chunkID], targetNode)
-			s.nodes[targetNode]++
-			log.Printf("Chunk %s asignado al nodo %s", chunkID, targetNode)
+			s.assignChunk(key, targetNode)
 		}
 		chunkMap[chunkID] = &pb.ChunkInfo{
 			Nodes: selectedNodes, // Lista de nodos que almacenan este chunk
+			Key:   key.proto(),
 		}
+		file.chunks = append(file.chunks, chunkRef{key: key})
 	}
+	s.addVersion(file)
 
-	return &pb.JoinResponse{Message: fmt.Sprintf("Archivo %s subido y fragmentado exitosamente.", fileName), ChunkMap: chunkMap}, nil
+	return &pb.JoinResponse{
+		Message:     fmt.Sprintf("Archivo %s (versión %d) subido y fragmentado exitosamente con %d réplicas por chunk.", fileName, file.version, replicas),
+		ChunkMap:    chunkMap,
+		StorageMode: StorageReplication,
+		FileId:      file.id,
+		Version:     file.version,
+	}, nil
 }
 
-// handleGet responde con los nodos que tienen los chunks del archivo solicitado.
-func (s *trackerServer) handleGet(fileName string) (*pb.JoinResponse, error) {
+// handlePutErasure agrupa los chunks del archivo en franjas de k shards de datos y m de
+// paridad, y ubica cada shard de una franja en un nodo distinto.
+func (s *trackerServer) handlePutErasure(file *fileRecord) (*pb.JoinResponse, error) {
+	fileName := file.key()
+	k, m := int(file.dataShards), int(file.parityShards)
+	chunks := int(file.sizeMb) // Suponiendo 1 chunk por MB
 	chunkMap := make(map[string]*pb.ChunkInfo)
 
-	// Recoger los chunks y sus nodos en una estructura temporal
-	for chunkID, nodes := range s.fileChunks {
-		if isValidChunk(fileName, chunkID) { // Filtrar los chunks del archivo específico
-			chunkMap[chunkID] = &pb.ChunkInfo{
-				Nodes: nodes, // Asignar la lista de nodos que almacenan este chunk
+	// Cada franja necesita k+m nodos distintos con espacio
+	available := 0
+	for _, info := range s.nodes {
+		if info.canStore(chunkSizeBytes) {
+			available++
+		}
+	}
+	if available < k+m {
+		return nil, status.Errorf(codes.FailedPrecondition, "se necesitan %d nodos con espacio para franjas de %d+%d shards, hay %d", k+m, k, m, available)
+	}
+
+	stripes := (chunks + k - 1) / k
+	for stripe := 0; stripe < stripes; stripe++ {
+		selectedNodes := s.selectNodesForChunk(fmt.Sprintf("%s-s%d", file.id, stripe+1), k+m, nil)
+		if len(selectedNodes) < k+m {
+			// Un shard sin nodo perdería datos del archivo: se deshacen las franjas ya asignadas
+			s.releaseChunks(file)
+			return nil, status.Errorf(codes.FailedPrecondition, "solo %d nodos con espacio para la franja %d, se necesitan %d para franjas de %d+%d shards",
+				len(selectedNodes), stripe+1, k+m, k, m)
+		}
+
+		for shard := 0; shard < k+m; shard++ {
+			// Los chunks de datos conservan su posición; los de paridad se numeran después
+			var index int
+			if shard < k {
+				index = stripe*k + shard + 1
+				if index > chunks {
+					continue // El archivo no alcanza a llenar la última franja
+				}
+			} else {
+				index = chunks + stripe*m + (shard - k) + 1
+			}
+			key := chunkKey{fileID: file.id, index: int32(index)}
+
+			targetNode := selectedNodes[shard]
+			s.assignChunk(key, targetNode)
+			chunkMap[key.String()] = &pb.ChunkInfo{
+				Nodes:  []string{targetNode},
+				Key:    key.proto(),
+				Stripe: int32(stripe),
+				Shard:  int32(shard),
+			}
+			file.chunks = append(file.chunks, chunkRef{key: key, stripe: int32(stripe), shard: int32(shard)})
+		}
+	}
+	s.addVersion(file)
+
+	return &pb.JoinResponse{
+		Message:      fmt.Sprintf("Archivo %s (versión %d) subido con erasure coding en %d franjas de %d+%d shards.", fileName, file.version, stripes, k, m),
+		ChunkMap:     chunkMap,
+		StorageMode:  StorageErasure,
+		DataShards:   file.dataShards,
+		ParityShards: file.parityShards,
+		FileId:       file.id,
+		Version:      file.version,
+	}, nil
+}
+
+// assignChunk registra que un nodo almacena un chunk y reserva su espacio.
+func (s *trackerServer) assignChunk(key chunkKey, targetNode string) {
+	s.fileChunks[key] = append(s.fileChunks[key], targetNode)
+	s.nodes[targetNode].chunks++
+	if s.nodes[targetNode].capacityBytes > 0 {
+		s.nodes[targetNode].freeBytes -= chunkSizeBytes // Reservar el espacio hasta el próximo heartbeat
+	}
+	log.Printf("Chunk %s asignado al nodo %s", key, targetNode)
+}
+
+// releaseChunks quita las asignaciones de los chunks de un archivo que no llegó a subirse y
+// devuelve el espacio reservado en sus nodos.
+func (s *trackerServer) releaseChunks(file *fileRecord) {
+	for _, chunk := range file.chunks {
+		for _, node := range s.fileChunks[chunk.key] {
+			if info, exists := s.nodes[node]; exists && info.capacityBytes > 0 {
+				info.freeBytes += chunkSizeBytes
 			}
+			s.dropReplica(chunk.key, node)
+		}
+	}
+	file.chunks = nil
+}
+
+// dropReplica quita a un nodo de la lista de nodos de un chunk y descuenta su carga.
+func (s *trackerServer) dropReplica(key chunkKey, node string) {
+	if remaining := removeNode(s.fileChunks[key], node); len(remaining) > 0 {
+		s.fileChunks[key] = remaining
+	} else {
+		delete(s.fileChunks, key)
+	}
+	if info, exists := s.nodes[node]; exists && info.chunks > 0 {
+		info.chunks--
+	}
+}
+
+// handleGet responde con los nodos que tienen los chunks del archivo solicitado, en la
+// versión indicada o en la más reciente si version es 0.
+func (s *trackerServer) handleGet(fileName fileKey, version int32) (*pb.JoinResponse, error) {
+	chunkMap := make(map[string]*pb.ChunkInfo)
+
+	file, exists := s.lookupVersion(fileName, version)
+	if !exists {
+		return &pb.JoinResponse{Message: "Archivo no encontrado en la red."}, nil
+	}
+
+	// Recoger todos los chunks del archivo; los que perdieron a todos sus nodos van sin
+	// nodos para que el cliente sepa que existen (y pueda reconstruirlos si usa paridad)
+	available := false
+	for _, chunk := range file.chunks {
+		nodes := s.fileChunks[chunk.key]
+		chunkMap[chunk.key.String()] = &pb.ChunkInfo{
+			Nodes:  nodes, // Asignar la lista de nodos que almacenan este chunk
+			Key:    chunk.key.proto(),
+			Stripe: chunk.stripe,
+			Shard:  chunk.shard,
 		}
+		available = available || len(nodes) > 0
 	}
 
-	if len(chunkMap) == 0 {
+	if !available {
 		return &pb.JoinResponse{Message: "Archivo no encontrado en la red."}, nil
 	}
 
-	log.Printf("Chunks encontrados para el archivo %s: %v", fileName, chunkMap)
+	log.Printf("Chunks encontrados para el archivo %s versión %d (id %s): %v", fileName, file.version, file.id, chunkMap)
 	return &pb.JoinResponse{
-		Message:  fmt.Sprintf("Nodos encontrados para los chunks del archivo %s", fileName),
-		ChunkMap: chunkMap, // Enviamos el mapa de chunks y nodos asociados
+		Message:      fmt.Sprintf("Nodos encontrados para los chunks del archivo %s (versión %d)", fileName, file.version),
+		ChunkMap:     chunkMap, // Enviamos el mapa de chunks y nodos asociados
+		StorageMode:  file.storageMode,
+		DataShards:   file.dataShards,
+		ParityShards: file.parityShards,
+		FileId:       file.id,
+		Version:      file.version,
+		Manifest:     file.manifest,
 	}, nil
 
 }
@@ -0,0 +1,225 @@
+package tracker
+
+import (
+	"context"
+	"fmt"
+	"log"
+	mathrand "math/rand"
+	"time"
+
+	pb "P2P_BitTorrent/pb"
+	"P2P_BitTorrent/security"
+)
+
+// Espera por defecto antes de corregir una diferencia entre el inventario de un nodo y el
+// mapa del tracker, para no tocar chunks de subidas o copias que todavía están en curso.
+const DefaultOrphanGrace = 10 * time.Minute
+
+// ReportInventory compara los chunks que reporta un nodo con los que el tracker le tiene
+// asignados. Los chunks de archivos vigentes que el nodo tiene sin que se le hayan asignado
+// quedan pendientes hasta que pase un desafío sobre ellos, y solo entonces se agregan al
+// mapa; si el nodo no es confiable o falló el desafío del chunk se borran de inmediato como
+// los de archivos eliminados. Los que nadie conoce se borran si siguen huérfanos pasado el
+// período de gracia, y los asignados que el nodo ya no tiene se quitan del mapa pasado ese
+// mismo período para que la re-replicación los complete.
+func (s *trackerServer) ReportInventory(ctx context.Context, req *pb.InventoryRequest) (*pb.InventoryResponse, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	nodeID := req.NodeId
+	info, err := s.authenticate(ctx, nodeID, req)
+	if err != nil {
+		return nil, err
+	}
+	if info == nil {
+		return &pb.InventoryResponse{Message: fmt.Sprintf("Nodo %s no registrado en la red.", nodeID)}, nil
+	}
+	info.lastSeen = time.Now()
+	now := time.Now()
+
+	// Chunks de todas las versiones vigentes
+	live := make(map[chunkKey]bool)
+	for _, file := range s.allVersions() {
+		for _, chunk := range file.chunks {
+			live[chunk.key] = true
+		}
+	}
+
+	reported := make(map[string]bool)
+	orphans := make(map[string]time.Time)
+	var toDelete []string
+	var toVerify []chunkKey
+	for _, chunkID := range req.ChunkIds {
+		reported[chunkID] = true
+
+		key, valid := parseChunkKey(chunkID)
+		if valid {
+			if _, deleted := s.tombstones[key.fileID]; deleted {
+				toDelete = append(toDelete, chunkID)
+				continue
+			}
+			if live[key] {
+				switch {
+				case contains(s.fileChunks[key], nodeID):
+				case info.unreliable || info.failedChunks[key]:
+					// Una réplica descartada por un desafío no se recupera desde el inventario
+					toDelete = append(toDelete, chunkID)
+					log.Printf("Chunk %s del nodo %s marcado para borrar: el nodo no pasó su desafío", chunkID, nodeID)
+				case s.pending[nodeID][key]:
+				default:
+					// Que el nodo liste el chunk no prueba que lo tenga: se le desafía antes de agregarlo
+					if s.pending[nodeID] == nil {
+						s.pending[nodeID] = make(map[chunkKey]bool)
+					}
+					s.pending[nodeID][key] = true
+					toVerify = append(toVerify, key)
+				}
+				continue
+			}
+		}
+
+		// Chunk huérfano: se borra solo si sigue sin dueño pasado el período de gracia
+		since, seen := s.orphans[nodeID][chunkID]
+		if !seen {
+			since = now
+		}
+		if now.Sub(since) >= s.orphanGrace {
+			toDelete = append(toDelete, chunkID)
+			log.Printf("Chunk huérfano %s del nodo %s marcado para borrar", chunkID, nodeID)
+		} else {
+			orphans[chunkID] = since
+		}
+	}
+	s.orphans[nodeID] = orphans
+
+	// Las réplicas que fallaron un desafío y el nodo ya borró pueden volver a asignársele como copias nuevas
+	for key := range info.failedChunks {
+		if !reported[key.String()] {
+			delete(info.failedChunks, key)
+		}
+	}
+
+	// Chunks asignados al nodo que no aparecen en su inventario
+	missing := make(map[chunkKey]time.Time)
+	dropped := 0
+	for key, holders := range s.fileChunks {
+		if reported[key.String()] || !contains(holders, nodeID) {
+			continue
+		}
+		since, seen := s.missing[nodeID][key]
+		if !seen {
+			since = now
+		}
+		if now.Sub(since) < s.orphanGrace {
+			missing[key] = since
+			continue
+		}
+
+		s.dropReplica(key, nodeID)
+		dropped++
+		log.Printf("Chunk %s quitado del nodo %s: no aparece en su inventario", key, nodeID)
+	}
+	s.missing[nodeID] = missing
+
+	// Los borrados pendientes de chunks que el nodo ya no tiene se dan por hechos
+	var garbage []chunkKey
+	for _, key := range s.garbage[nodeID] {
+		if reported[key.String()] {
+			garbage = append(garbage, key)
+		}
+	}
+	if len(garbage) > 0 {
+		s.garbage[nodeID] = garbage
+	} else {
+		delete(s.garbage, nodeID)
+	}
+
+	if dropped > 0 {
+		go s.repairUnderReplicated()
+	}
+	if len(toVerify) > 0 {
+		go s.verifyInventory(nodeID, toVerify)
+	}
+
+	return &pb.InventoryResponse{
+		Message:        fmt.Sprintf("Inventario reconciliado: %d chunks por verificar, %d quitados, %d a borrar.", len(toVerify), dropped, len(toDelete)),
+		DeleteChunkIds: toDelete,
+	}, nil
+}
+
+// verifyInventory desafía a un nodo por los chunks que reportó en su inventario sin tenerlos
+// asignados y lo agrega como nodo de los que pasan el desafío; los que falla cuentan como
+// cualquier desafío fallado. Los chunks de archivos sin manifiesto, o cuyo resumen se
+// descartó, no se pueden verificar: no se agregan y se vuelven a considerar en el próximo
+// inventario, por si el manifiesto se publica después.
+func (s *trackerServer) verifyInventory(nodeID string, keys []chunkKey) {
+	s.mu.Lock()
+	digests := make(map[chunkKey]*pb.ChunkDigest)
+	for _, key := range keys {
+		file := s.findFile(key.fileID)
+		if file == nil || file.badDigests || int(key.index) > len(file.manifest.GetStoredChunks()) {
+			delete(s.pending[nodeID], key)
+			continue
+		}
+		digests[key] = file.manifest.StoredChunks[key.index-1]
+	}
+	s.mu.Unlock()
+
+	for key, digest := range digests {
+		_, err := s.sendChallenge(nodeID, key, digest, mathrand.Int63n(security.ChunkBlocks(digest.Size)))
+
+		s.mu.Lock()
+		delete(s.pending[nodeID], key)
+		info, active := s.nodes[nodeID]
+		_, deleted := s.tombstones[key.fileID]
+		switch {
+		case !active || deleted || s.findFile(key.fileID) == nil || contains(s.fileChunks[key], nodeID):
+			// El nodo salió de la red, el archivo ya no está vigente o el chunk se le asignó mientras tanto
+		case err != nil:
+			log.Printf("El nodo %s reportó el chunk %s pero falló su desafío: %v", nodeID, key, err)
+			s.challengeFailed(nodeID, key)
+		case !info.unreliable && !info.failedChunks[key]:
+			s.fileChunks[key] = append(s.fileChunks[key], nodeID)
+			info.chunks++
+			log.Printf("Chunk %s agregado al nodo %s según su inventario, tras pasar su desafío", key, nodeID)
+		}
+		s.mu.Unlock()
+	}
+}
+
+// ReportCorruptChunks quita al nodo de los chunks que su scrubber puso en cuarentena y
+// lanza la re-replicación desde las réplicas sanas.
+func (s *trackerServer) ReportCorruptChunks(ctx context.Context, req *pb.CorruptChunksRequest) (*pb.CorruptChunksResponse, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	nodeID := req.NodeId
+	info, err := s.authenticate(ctx, nodeID, req)
+	if err != nil {
+		return nil, err
+	}
+	if info == nil {
+		return &pb.CorruptChunksResponse{Message: fmt.Sprintf("Nodo %s no registrado en la red.", nodeID)}, nil
+	}
+
+	dropped := 0
+	for _, chunkID := range req.ChunkIds {
+		key, valid := parseChunkKey(chunkID)
+		if !valid || !contains(s.fileChunks[key], nodeID) {
+			continue
+		}
+
+		s.dropReplica(key, nodeID)
+		if len(s.fileChunks[key]) == 0 {
+			log.Printf("El chunk %s no tiene réplicas sanas", chunkID)
+		}
+		dropped++
+		log.Printf("Réplica corrupta del chunk %s en el nodo %s descartada", chunkID, nodeID)
+	}
+
+	if dropped > 0 {
+		go s.repairUnderReplicated()
+	}
+
+	return &pb.CorruptChunksResponse{Message: fmt.Sprintf("%d réplicas corruptas descartadas, se re-replicarán desde otros nodos.", dropped)}, nil
+}
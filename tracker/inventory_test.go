@@ -0,0 +1,83 @@
+package tracker
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	pb "P2P_BitTorrent/pb"
+	"P2P_BitTorrent/security"
+)
+
+func TestInventoryChunksNeedChallenge(t *testing.T) {
+	chunk := make([]byte, 2*security.ChallengeBlockSize+10)
+	for i := range chunk {
+		chunk[i] = byte(i * 3)
+	}
+	corrupted := append([]byte(nil), chunk...)
+	for i := range corrupted {
+		corrupted[i] ^= 0xff
+	}
+
+	tests := []struct {
+		name        string
+		held        []byte // Contenido que guarda el nodo.
+		noManifest  bool
+		wantAdopted bool
+		wantFailed  bool
+	}{
+		{name: "el nodo guarda el chunk", held: chunk, wantAdopted: true},
+		{name: "el nodo guarda otro contenido", held: corrupted, wantFailed: true},
+		{name: "archivo sin manifiesto", held: chunk, noManifest: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := NewTrackerServer(Config{})
+			node := newTestNode(t, s, startHolder(t, tt.held))
+			key := chunkKey{fileID: "archivo-1", index: 1}
+			file := &fileRecord{id: key.fileID, name: "archivo", chunks: []chunkRef{{key: key}}, createdAt: time.Now()}
+			if !tt.noManifest {
+				file.manifest = &pb.Manifest{FileId: key.fileID, StoredChunks: []*pb.ChunkDigest{security.DigestChunk(chunk)}}
+			}
+			s.files[file.key()] = []*fileRecord{file}
+
+			req := &pb.InventoryRequest{NodeId: node.id, ChunkIds: []string{key.String()}}
+			if err := node.identity.Sign(req); err != nil {
+				t.Fatalf("Sign: %v", err)
+			}
+			res, err := s.ReportInventory(context.Background(), req)
+			if err != nil {
+				t.Fatalf("ReportInventory: %v", err)
+			}
+			if len(res.DeleteChunkIds) > 0 {
+				t.Errorf("se pidió borrar %v", res.DeleteChunkIds)
+			}
+
+			// El chunk no se agrega hasta que termina su desafío
+			deadline := time.Now().Add(5 * time.Second)
+			for {
+				s.mu.Lock()
+				pending := len(s.pending[node.id])
+				adopted := contains(s.fileChunks[key], node.id)
+				failed := s.nodes[node.id].failedChunks[key]
+				s.mu.Unlock()
+				if pending == 0 {
+					if adopted != tt.wantAdopted {
+						t.Errorf("chunk agregado al nodo = %v, se esperaba %v", adopted, tt.wantAdopted)
+					}
+					if failed != tt.wantFailed {
+						t.Errorf("chunk marcado como fallado = %v, se esperaba %v", failed, tt.wantFailed)
+					}
+					return
+				}
+				if adopted {
+					t.Fatal("el chunk se agregó antes de pasar su desafío")
+				}
+				if time.Now().After(deadline) {
+					t.Fatal("el desafío del chunk reportado no terminó")
+				}
+				time.Sleep(10 * time.Millisecond)
+			}
+		})
+	}
+}
@@ -0,0 +1,243 @@
+package tracker
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+
+	pb "P2P_BitTorrent/pb"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Archivos por página de ListFiles y SearchFiles.
+const (
+	DefaultPageSize = 50
+	MaxPageSize     = 1000
+)
+
+// Criterios de orden de ListFiles y SearchFiles. Los empates se ordenan por nombre.
+const (
+	SortByName = "name"
+	SortBySize = "size" // Tamaño de la versión más reciente.
+	SortByDate = "date" // Fecha de subida de la versión más reciente.
+)
+
+// listOptions son el orden y la paginación de una lista de archivos.
+type listOptions struct {
+	sortBy     string
+	descending bool
+	pageSize   int32
+	pageToken  string
+}
+
+// pageCursor es la posición de la última entrada de una página. Las páginas siguientes
+// empiezan después de ella aunque entre tanto se suban o eliminen archivos.
+type pageCursor struct {
+	sortBy     string
+	descending bool
+	value      int64  // Tamaño o fecha de la entrada (0 al ordenar por nombre).
+	name       string // Nombre de la entrada.
+}
+
+// encode convierte el cursor en el token de página que se entrega al cliente.
+func (c pageCursor) encode() string {
+	raw := fmt.Sprintf("%s\x00%t\x00%d\x00%s", c.sortBy, c.descending, c.value, c.name)
+	return base64.RawURLEncoding.EncodeToString([]byte(raw))
+}
+
+// decodeCursor interpreta un token de página.
+func decodeCursor(token string) (pageCursor, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return pageCursor{}, err
+	}
+	fields := strings.SplitN(string(raw), "\x00", 4)
+	if len(fields) != 4 {
+		return pageCursor{}, fmt.Errorf("token con %d campos", len(fields))
+	}
+	descending, err := strconv.ParseBool(fields[1])
+	if err != nil {
+		return pageCursor{}, err
+	}
+	value, err := strconv.ParseInt(fields[2], 10, 64)
+	if err != nil {
+		return pageCursor{}, err
+	}
+	return pageCursor{sortBy: fields[0], descending: descending, value: value, name: fields[3]}, nil
+}
+
+// sortValue devuelve el valor por el que se ordena una entrada; el nombre desempata.
+func sortValue(entry *pb.FileEntry, sortBy string) int64 {
+	switch sortBy {
+	case SortBySize:
+		return int64(entry.FileSizeMb)
+	case SortByDate:
+		return entry.UpdatedAt
+	}
+	return 0
+}
+
+// positionBefore indica si la posición (value, name) va antes que (otherValue, otherName)
+// en el orden pedido.
+func positionBefore(value int64, name string, otherValue int64, otherName string, descending bool) bool {
+	if value != otherValue {
+		return (value < otherValue) != descending
+	}
+	if name == otherName {
+		return false
+	}
+	return (name < otherName) != descending
+}
+
+// listFiles devuelve una página de los archivos del namespace cuyo nombre cumple match y
+// que la clave puede descargar.
+func (s *trackerServer) listFiles(info *nodeInfo, namespace string, match func(string) bool, opts listOptions) (*pb.ListFilesResponse, error) {
+	switch opts.sortBy {
+	case "":
+		opts.sortBy = SortByName
+	case SortByName, SortBySize, SortByDate:
+	default:
+		return nil, status.Errorf(codes.InvalidArgument, "orden desconocido: %s", opts.sortBy)
+	}
+	if opts.pageSize < 0 {
+		return nil, status.Errorf(codes.InvalidArgument, "tamaño de página inválido: %d", opts.pageSize)
+	}
+	if opts.pageSize == 0 {
+		opts.pageSize = DefaultPageSize
+	}
+	if opts.pageSize > MaxPageSize {
+		opts.pageSize = MaxPageSize
+	}
+	if _, err := s.lookupNamespace(namespace); err != nil {
+		return nil, err
+	}
+
+	var entries []*pb.FileEntry
+	for key, versions := range s.files {
+		if key.namespace != namespace || len(versions) == 0 || !match(key.name) {
+			continue
+		}
+		public := true
+		if acl, exists := s.acls[key]; exists {
+			if !acl.canRead(info.publicKey) {
+				continue
+			}
+			public = acl.public
+		}
+		latest := versions[len(versions)-1]
+		entries = append(entries, &pb.FileEntry{
+			Name:            key.name,
+			Namespace:       key.namespace,
+			FileSizeMb:      latest.sizeMb,
+			Version:         latest.version,
+			Versions:        int32(len(versions)),
+			UpdatedAt:       latest.createdAt.Unix(),
+			Public:          public,
+			CollectionFiles: int32(len(latest.manifest.GetEntries())),
+		})
+	}
+
+	sort.Slice(entries, func(i, j int) bool {
+		return positionBefore(sortValue(entries[i], opts.sortBy), entries[i].Name,
+			sortValue(entries[j], opts.sortBy), entries[j].Name, opts.descending)
+	})
+
+	// Saltar lo que ya se entregó en las páginas anteriores
+	if opts.pageToken != "" {
+		cursor, err := decodeCursor(opts.pageToken)
+		if err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "token de página inválido: %v", err)
+		}
+		if cursor.sortBy != opts.sortBy || cursor.descending != opts.descending {
+			return nil, status.Errorf(codes.InvalidArgument, "el token de página corresponde a otro orden")
+		}
+		start := sort.Search(len(entries), func(i int) bool {
+			return positionBefore(cursor.value, cursor.name, sortValue(entries[i], opts.sortBy), entries[i].Name, opts.descending)
+		})
+		entries = entries[start:]
+	}
+
+	res := &pb.ListFilesResponse{}
+	if len(entries) > int(opts.pageSize) {
+		entries = entries[:opts.pageSize]
+		last := entries[len(entries)-1]
+		res.NextPageToken = pageCursor{
+			sortBy:     opts.sortBy,
+			descending: opts.descending,
+			value:      sortValue(last, opts.sortBy),
+			name:       last.Name,
+		}.encode()
+	}
+	res.Files = entries
+	return res, nil
+}
+
+// ListFiles devuelve los archivos de un namespace que empiezan con el prefijo indicado.
+func (s *trackerServer) ListFiles(ctx context.Context, req *pb.ListFilesRequest) (*pb.ListFilesResponse, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	info, err := s.authenticate(ctx, req.NodeId, req)
+	if err != nil {
+		return nil, err
+	}
+	if info == nil {
+		return nil, status.Errorf(codes.PermissionDenied, "nodo %s no registrado en la red", req.NodeId)
+	}
+
+	match := func(name string) bool { return strings.HasPrefix(name, req.Prefix) }
+	return s.listFiles(info, req.Namespace, match, listOptions{
+		sortBy:     req.SortBy,
+		descending: req.Descending,
+		pageSize:   req.PageSize,
+		pageToken:  req.PageToken,
+	})
+}
+
+// SearchFiles devuelve los archivos de un namespace cuyo nombre contiene la subcadena o
+// cumple el patrón glob indicado. En los patrones, '*' y '?' no abarcan '/'.
+func (s *trackerServer) SearchFiles(ctx context.Context, req *pb.SearchFilesRequest) (*pb.ListFilesResponse, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	info, err := s.authenticate(ctx, req.NodeId, req)
+	if err != nil {
+		return nil, err
+	}
+	if info == nil {
+		return nil, status.Errorf(codes.PermissionDenied, "nodo %s no registrado en la red", req.NodeId)
+	}
+	if req.Query == "" {
+		return nil, status.Errorf(codes.InvalidArgument, "la búsqueda necesita un texto o un patrón")
+	}
+
+	query := req.Query
+	normalize := func(name string) string { return name }
+	if req.IgnoreCase {
+		query = strings.ToLower(query)
+		normalize = strings.ToLower
+	}
+	match := func(name string) bool { return strings.Contains(normalize(name), query) }
+	if req.Glob {
+		if _, err := path.Match(query, ""); err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "patrón inválido: %s", req.Query)
+		}
+		match = func(name string) bool {
+			matched, _ := path.Match(query, normalize(name))
+			return matched
+		}
+	}
+
+	return s.listFiles(info, req.Namespace, match, listOptions{
+		sortBy:     req.SortBy,
+		descending: req.Descending,
+		pageSize:   req.PageSize,
+		pageToken:  req.PageToken,
+	})
+}
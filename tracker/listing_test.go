@@ -0,0 +1,169 @@
+package tracker
+
+import (
+	"crypto/ed25519"
+	"encoding/base64"
+	"slices"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// newListingServer crea un tracker con un archivo por cada nombre, con el tamaño indicado y
+// subidos en orden alfabético un minuto después del anterior. El archivo "oculto" es privado
+// de otro nodo.
+func newListingServer(sizes map[string]int32) *trackerServer {
+	s := NewTrackerServer(Config{})
+	names := make([]string, 0, len(sizes))
+	for name := range sizes {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	start := time.Now().Add(-time.Hour)
+	for i, name := range names {
+		file := &fileRecord{id: name + "-id", name: name, sizeMb: sizes[name], version: 1, createdAt: start.Add(time.Duration(i) * time.Minute)}
+		s.files[file.key()] = []*fileRecord{file}
+	}
+	owner := make(ed25519.PublicKey, ed25519.PublicKeySize)
+	s.acls[fileKey{name: "oculto"}] = newFileACL(owner, false)
+	return s
+}
+
+// listAll recorre todas las páginas de una lista y devuelve los nombres en orden.
+func listAll(t *testing.T, s *trackerServer, info *nodeInfo, opts listOptions) []string {
+	t.Helper()
+	var names []string
+	for page := 0; ; page++ {
+		res, err := s.listFiles(info, "", func(string) bool { return true }, opts)
+		if err != nil {
+			t.Fatalf("página %d: %v", page, err)
+		}
+		if len(res.Files) > int(opts.pageSize) {
+			t.Fatalf("página %d con %d archivos, el máximo es %d", page, len(res.Files), opts.pageSize)
+		}
+		for _, file := range res.Files {
+			names = append(names, file.Name)
+		}
+		if res.NextPageToken == "" {
+			return names
+		}
+		if page > len(s.files) {
+			t.Fatal("la paginación no termina")
+		}
+		opts.pageToken = res.NextPageToken
+	}
+}
+
+func TestListFilesPagination(t *testing.T) {
+	sizes := map[string]int32{"a": 2, "b": 1, "c": 2, "d": 2, "e": 3, "f": 1, "oculto": 1}
+	tests := []struct {
+		name       string
+		sortBy     string
+		descending bool
+		pageSize   int32
+		want       []string
+	}{
+		{name: "por nombre", pageSize: 2, want: []string{"a", "b", "c", "d", "e", "f"}},
+		{name: "por nombre descendente", sortBy: SortByName, descending: true, pageSize: 4, want: []string{"f", "e", "d", "c", "b", "a"}},
+		{name: "empates en el límite de página", sortBy: SortBySize, pageSize: 2, want: []string{"b", "f", "a", "c", "d", "e"}},
+		{name: "empates en una página de uno", sortBy: SortBySize, pageSize: 1, want: []string{"b", "f", "a", "c", "d", "e"}},
+		{name: "empates en orden descendente", sortBy: SortBySize, descending: true, pageSize: 2, want: []string{"e", "d", "c", "a", "f", "b"}},
+		{name: "por fecha", sortBy: SortByDate, descending: true, pageSize: 5, want: []string{"f", "e", "d", "c", "b", "a"}},
+		{name: "una sola página", sortBy: SortBySize, pageSize: 6, want: []string{"b", "f", "a", "c", "d", "e"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := newListingServer(sizes)
+			info := &nodeInfo{publicKey: make(ed25519.PublicKey, ed25519.PublicKeySize)}
+			info.publicKey[0] = 1
+			got := listAll(t, s, info, listOptions{sortBy: tt.sortBy, descending: tt.descending, pageSize: tt.pageSize})
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("archivos %v, se esperaba %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestListFilesCursorSurvivesChanges(t *testing.T) {
+	s := newListingServer(map[string]int32{"a": 2, "b": 1, "c": 2, "d": 2, "e": 3, "f": 1})
+	info := &nodeInfo{}
+	all := func(string) bool { return true }
+
+	first, err := s.listFiles(info, "", all, listOptions{sortBy: SortBySize, pageSize: 3})
+	if err != nil {
+		t.Fatalf("primera página: %v", err)
+	}
+	// Se elimina el último archivo entregado y se sube uno que iría en la primera página
+	delete(s.files, fileKey{name: "a"})
+	file := &fileRecord{id: "0-id", name: "0", sizeMb: 1, version: 1, createdAt: time.Now()}
+	s.files[file.key()] = []*fileRecord{file}
+
+	second, err := s.listFiles(info, "", all, listOptions{sortBy: SortBySize, pageSize: 3, pageToken: first.NextPageToken})
+	if err != nil {
+		t.Fatalf("segunda página: %v", err)
+	}
+	var got []string
+	for _, file := range second.Files {
+		got = append(got, file.Name)
+	}
+	if want := []string{"c", "d", "e"}; !slices.Equal(got, want) {
+		t.Errorf("segunda página %v, se esperaba %v", got, want)
+	}
+}
+
+func TestListFilesRejectsInvalidOptions(t *testing.T) {
+	s := newListingServer(map[string]int32{"a": 1, "b": 2, "c": 3})
+	first, err := s.listFiles(&nodeInfo{}, "", func(string) bool { return true }, listOptions{sortBy: SortBySize, pageSize: 1})
+	if err != nil {
+		t.Fatalf("primera página: %v", err)
+	}
+	token := first.NextPageToken
+
+	tests := []struct {
+		name string
+		opts listOptions
+		want codes.Code
+	}{
+		{name: "mismo orden", opts: listOptions{sortBy: SortBySize, pageSize: 1, pageToken: token}, want: codes.OK},
+		{name: "token con otro criterio", opts: listOptions{sortBy: SortByName, pageSize: 1, pageToken: token}, want: codes.InvalidArgument},
+		{name: "token con el orden por defecto", opts: listOptions{pageSize: 1, pageToken: token}, want: codes.InvalidArgument},
+		{name: "token con otra dirección", opts: listOptions{sortBy: SortBySize, descending: true, pageSize: 1, pageToken: token}, want: codes.InvalidArgument},
+		{name: "token que no es base64", opts: listOptions{pageToken: "no es un token"}, want: codes.InvalidArgument},
+		{name: "token con campos de menos", opts: listOptions{pageToken: base64.RawURLEncoding.EncodeToString([]byte("name\x00false"))}, want: codes.InvalidArgument},
+		{name: "orden desconocido", opts: listOptions{sortBy: "color"}, want: codes.InvalidArgument},
+		{name: "tamaño de página negativo", opts: listOptions{pageSize: -1}, want: codes.InvalidArgument},
+		{name: "namespace inexistente", opts: listOptions{}, want: codes.NotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			namespace := ""
+			if tt.want == codes.NotFound {
+				namespace = "inexistente"
+			}
+			_, err := s.listFiles(&nodeInfo{}, namespace, func(string) bool { return true }, tt.opts)
+			if got := status.Code(err); got != tt.want {
+				t.Errorf("código %v, se esperaba %v (%v)", got, tt.want, err)
+			}
+		})
+	}
+}
+
+func TestDecodeCursor(t *testing.T) {
+	cursors := []pageCursor{
+		{sortBy: SortByName, name: "a"},
+		{sortBy: SortBySize, descending: true, value: 42, name: "dir/archivo con espacios"},
+		{sortBy: SortByDate, value: -5, name: ""},
+	}
+	for _, cursor := range cursors {
+		got, err := decodeCursor(cursor.encode())
+		if err != nil {
+			t.Errorf("decodeCursor(%+v): %v", cursor, err)
+			continue
+		}
+		if got != cursor {
+			t.Errorf("decodeCursor devolvió %+v, se esperaba %+v", got, cursor)
+		}
+	}
+}
@@ -0,0 +1,80 @@
+package tracker
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"fmt"
+	"log"
+
+	pb "P2P_BitTorrent/pb"
+	"P2P_BitTorrent/security"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// PublishManifest guarda el manifiesto firmado de un archivo. Solo lo puede publicar el nodo
+// que subió el archivo, firmado con la clave con la que está registrado, y una sola vez.
+func (s *trackerServer) PublishManifest(ctx context.Context, req *pb.PublishManifestRequest) (*pb.PublishManifestResponse, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	info, err := s.authenticate(ctx, req.NodeId, req)
+	if err != nil {
+		return nil, err
+	}
+	if info == nil {
+		return nil, status.Errorf(codes.PermissionDenied, "nodo %s no registrado en la red", req.NodeId)
+	}
+
+	manifest := req.Manifest
+	if manifest == nil {
+		return nil, status.Error(codes.InvalidArgument, "falta el manifiesto")
+	}
+	file := s.findFile(manifest.FileId)
+	if file == nil {
+		return nil, status.Errorf(codes.NotFound, "archivo %s no encontrado en la red", manifest.FileId)
+	}
+	if file.uploader != req.NodeId {
+		return nil, status.Errorf(codes.PermissionDenied, "el archivo %s fue subido por otro nodo", file.name)
+	}
+	if file.manifest != nil {
+		return nil, status.Errorf(codes.AlreadyExists, "el archivo %s ya tiene manifiesto", file.name)
+	}
+	if !bytes.Equal(manifest.PublisherKey, info.publicKey) {
+		return nil, status.Error(codes.PermissionDenied, "el manifiesto no está firmado con la clave del nodo")
+	}
+	if err := security.VerifyManifest(manifest); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	if manifest.FileName != file.name || len(manifest.ChunkHashes) != int(file.sizeMb) {
+		return nil, status.Errorf(codes.InvalidArgument, "el manifiesto no corresponde al archivo %s", file.name)
+	}
+	if err := security.CheckCollection(manifest); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	// Los desafíos de almacenamiento se verifican con el resumen de cada chunk guardado
+	if len(manifest.StoredChunks) != len(file.chunks) {
+		return nil, status.Errorf(codes.InvalidArgument, "el manifiesto resume %d chunks y el archivo %s guarda %d", len(manifest.StoredChunks), file.name, len(file.chunks))
+	}
+	for i, digest := range manifest.StoredChunks {
+		if len(digest.BlockRoot) != sha256.Size || digest.Size < 0 {
+			return nil, status.Errorf(codes.InvalidArgument, "resumen inválido para el chunk %d", i+1)
+		}
+	}
+
+	file.manifest = manifest
+	log.Printf("Manifiesto del archivo %s versión %d (id %s) publicado por %s", file.name, file.version, file.id, req.NodeId)
+	return &pb.PublishManifestResponse{Message: fmt.Sprintf("Manifiesto del archivo %s publicado.", file.name)}, nil
+}
+
+// findFile busca una versión de un archivo por su ID.
+func (s *trackerServer) findFile(fileID string) *fileRecord {
+	for _, file := range s.allVersions() {
+		if file.id == fileID {
+			return file
+		}
+	}
+	return nil
+}
@@ -0,0 +1,82 @@
+package tracker
+
+import (
+	"context"
+	"testing"
+
+	pb "P2P_BitTorrent/pb"
+	"P2P_BitTorrent/security"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestPublishManifest(t *testing.T) {
+	tests := []struct {
+		name     string
+		edit     func(m *pb.Manifest) // Cambios al manifiesto antes de firmarlo.
+		tamper   func(m *pb.Manifest) // Cambios después de firmarlo.
+		signer   int                  // Nodo que firma el manifiesto.
+		sender   int                  // Nodo que lo publica.
+		twice    bool                 // Se publica dos veces.
+		wantCode codes.Code
+	}{
+		{name: "manifiesto válido", wantCode: codes.OK},
+		{name: "publicado dos veces", twice: true, wantCode: codes.AlreadyExists},
+		{name: "publicado por otro nodo", signer: 1, sender: 1, wantCode: codes.PermissionDenied},
+		{name: "firmado con otra clave", signer: 1, wantCode: codes.PermissionDenied},
+		{name: "modificado tras firmarlo", tamper: func(m *pb.Manifest) { m.FileSize++ }, wantCode: codes.InvalidArgument},
+		{name: "otro nombre", edit: func(m *pb.Manifest) { m.FileName = "otro" }, wantCode: codes.InvalidArgument},
+		{name: "chunks de menos", edit: func(m *pb.Manifest) { m.ChunkHashes = m.ChunkHashes[:1] }, wantCode: codes.InvalidArgument},
+		{name: "resúmenes de menos", edit: func(m *pb.Manifest) { m.StoredChunks = m.StoredChunks[:1] }, wantCode: codes.InvalidArgument},
+		{name: "resumen inválido", edit: func(m *pb.Manifest) { m.StoredChunks[0].BlockRoot = []byte{1} }, wantCode: codes.InvalidArgument},
+		{name: "archivo desconocido", edit: func(m *pb.Manifest) { m.FileId = "desconocido" }, wantCode: codes.NotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, nodes := newTestNetwork(t, Config{Replicas: 1}, 2)
+			res, err := nodes[0].join(s, &pb.JoinRequest{Action: "put", FileName: "archivo", FileSizeMb: 2})
+			if err != nil {
+				t.Fatalf("put: %v", err)
+			}
+
+			manifest := &pb.Manifest{FileId: res.FileId, FileName: "archivo", FileSize: 2 << 20}
+			for i := 0; i < 2; i++ {
+				data := []byte{byte(i)}
+				manifest.ChunkHashes = append(manifest.ChunkHashes, data)
+				manifest.StoredChunks = append(manifest.StoredChunks, security.DigestChunk(data))
+			}
+			if tt.edit != nil {
+				tt.edit(manifest)
+			}
+			if err := nodes[tt.signer].identity.SignManifest(manifest); err != nil {
+				t.Fatalf("SignManifest: %v", err)
+			}
+			if tt.tamper != nil {
+				tt.tamper(manifest)
+			}
+
+			publish := func() error {
+				req := &pb.PublishManifestRequest{NodeId: nodes[tt.sender].id, Manifest: manifest}
+				if err := nodes[tt.sender].identity.Sign(req); err != nil {
+					t.Fatalf("Sign: %v", err)
+				}
+				_, err := s.PublishManifest(context.Background(), req)
+				return err
+			}
+			err = publish()
+			if tt.twice {
+				if err != nil {
+					t.Fatalf("primera publicación: %v", err)
+				}
+				err = publish()
+			}
+			if status.Code(err) != tt.wantCode {
+				t.Errorf("PublishManifest devolvió %v, se esperaba %v", err, tt.wantCode)
+			}
+			if published := s.findFile(res.FileId).manifest != nil; published != (tt.wantCode == codes.OK || tt.twice) {
+				t.Errorf("manifiesto publicado = %v", published)
+			}
+		})
+	}
+}
@@ -0,0 +1,160 @@
+package tracker
+
+import (
+	"context"
+	"crypto/ed25519"
+	"fmt"
+	"log"
+	"regexp"
+	"sort"
+	"time"
+
+	pb "P2P_BitTorrent/pb"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Nombres válidos de namespace: no pueden contener ':', que separa el namespace del nombre
+// del archivo en los mensajes.
+var namespaceName = regexp.MustCompile(`^[A-Za-z0-9._-]+$`)
+
+// namespace agrupa archivos de un equipo, que pueden usar los mismos nombres que los de
+// otros namespaces. El namespace por defecto ("") no tiene dueño, cuota ni permisos por defecto.
+type namespace struct {
+	name       string    // Nombre del namespace.
+	quotaBytes int64     // Bytes que pueden ocupar todas las versiones de sus archivos (0 = sin límite).
+	defaults   *fileACL  // Permisos de los archivos nuevos; su dueño es el dueño del namespace.
+	createdAt  time.Time // Momento de la creación.
+}
+
+// lookupNamespace busca un namespace por nombre; el namespace por defecto devuelve nil.
+func (s *trackerServer) lookupNamespace(name string) (*namespace, error) {
+	if name == "" {
+		return nil, nil
+	}
+	ns, exists := s.namespaces[name]
+	if !exists {
+		return nil, status.Errorf(codes.NotFound, "namespace %s no encontrado", name)
+	}
+	return ns, nil
+}
+
+// namespaceUsage devuelve los bytes que ocupan todas las versiones de los archivos de un
+// namespace y la cantidad de archivos distintos.
+func (s *trackerServer) namespaceUsage(name string) (int64, int) {
+	var used int64
+	files := 0
+	for key, versions := range s.files {
+		if key.namespace != name || len(versions) == 0 {
+			continue
+		}
+		files++
+		for _, file := range versions {
+			used += int64(file.sizeMb) << 20
+		}
+	}
+	return used, files
+}
+
+// checkPut verifica que la clave pueda subir una versión del archivo: si el archivo ya
+// existe, con sus permisos; si es nuevo, con los permisos por defecto de su namespace.
+// También verifica que la subida no supere las cuotas del namespace y del nodo.
+func (s *trackerServer) checkPut(key fileKey, uploader ed25519.PublicKey, sizeMb int32) error {
+	ns, err := s.lookupNamespace(key.namespace)
+	if err != nil {
+		return err
+	}
+	if _, exists := s.acls[key]; exists {
+		if err := s.checkAccess(key, uploader, true); err != nil {
+			return err
+		}
+	} else if ns != nil && !ns.defaults.canWrite(uploader) {
+		return status.Errorf(codes.PermissionDenied, "no tiene permiso para subir archivos al namespace %s", ns.name)
+	}
+	return s.checkStorageQuota(ns, uploader, int64(sizeMb)<<20)
+}
+
+// namespaceInfo describe el namespace con su uso actual.
+func (s *trackerServer) namespaceInfo(ns *namespace) *pb.NamespaceInfo {
+	acl := ns.defaults.proto()
+	used, files := s.namespaceUsage(ns.name)
+	return &pb.NamespaceInfo{
+		Name:       ns.name,
+		Owner:      acl.Owner,
+		QuotaBytes: ns.quotaBytes,
+		UsedBytes:  used,
+		Files:      int32(files),
+		Public:     acl.Public,
+		Readers:    acl.Readers,
+		Writers:    acl.Writers,
+	}
+}
+
+// UpdateNamespace crea un namespace, con quien lo pide como dueño, o cambia su cuota y los
+// permisos por defecto de sus archivos nuevos. Solo el dueño puede cambiarlo; los permisos
+// de los archivos que ya existen no cambian.
+func (s *trackerServer) UpdateNamespace(ctx context.Context, req *pb.NamespaceRequest) (*pb.NamespaceResponse, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	info, err := s.authenticate(ctx, req.NodeId, req)
+	if err != nil {
+		return nil, err
+	}
+	if info == nil {
+		return nil, status.Errorf(codes.PermissionDenied, "nodo %s no registrado en la red", req.NodeId)
+	}
+	if !namespaceName.MatchString(req.Name) {
+		return nil, status.Errorf(codes.InvalidArgument, "nombre de namespace inválido: %q", req.Name)
+	}
+	if req.QuotaMb < -1 {
+		return nil, status.Errorf(codes.InvalidArgument, "cuota inválida: %d MB", req.QuotaMb)
+	}
+
+	ns, exists := s.namespaces[req.Name]
+	message := fmt.Sprintf("Namespace %s actualizado.", req.Name)
+	if !exists {
+		ns = &namespace{name: req.Name, defaults: newFileACL(info.publicKey, true), createdAt: time.Now()}
+		message = fmt.Sprintf("Namespace %s creado.", req.Name)
+	} else if string(info.publicKey) != ns.defaults.owner {
+		return nil, status.Errorf(codes.PermissionDenied, "solo el dueño puede cambiar el namespace %s", req.Name)
+	}
+
+	// Aplicar los cambios sobre una copia, para no dejar el namespace a medio cambiar si alguno es inválido
+	defaults := *ns.defaults
+	defaults.readers = copySet(ns.defaults.readers)
+	defaults.writers = copySet(ns.defaults.writers)
+	if err := defaults.apply(req); err != nil {
+		return nil, err
+	}
+	ns.defaults = &defaults
+	switch {
+	case req.QuotaMb > 0:
+		ns.quotaBytes = req.QuotaMb << 20
+	case req.QuotaMb == -1:
+		ns.quotaBytes = 0
+	}
+
+	s.namespaces[req.Name] = ns
+	log.Printf("Namespace %s actualizado por %s (cuota: %d bytes)", req.Name, req.NodeId, ns.quotaBytes)
+	return &pb.NamespaceResponse{Message: message, Namespace: s.namespaceInfo(ns)}, nil
+}
+
+// ListNamespaces devuelve los namespaces creados, ordenados por nombre, con su uso actual.
+func (s *trackerServer) ListNamespaces(ctx context.Context, req *pb.ListNamespacesRequest) (*pb.ListNamespacesResponse, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	names := make([]string, 0, len(s.namespaces))
+	for name := range s.namespaces {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	res := &pb.ListNamespacesResponse{}
+	for _, name := range names {
+		res.Namespaces = append(res.Namespaces, s.namespaceInfo(s.namespaces[name]))
+	}
+	return res, nil
+}
@@ -0,0 +1,115 @@
+package tracker
+
+import (
+	"context"
+	"testing"
+
+	pb "P2P_BitTorrent/pb"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// updateNamespace firma y envía una solicitud de UpdateNamespace en nombre del nodo.
+func (n *testNode) updateNamespace(s *trackerServer, req *pb.NamespaceRequest) (*pb.NamespaceResponse, error) {
+	req.NodeId = n.id
+	if err := n.identity.Sign(req); err != nil {
+		return nil, err
+	}
+	return s.UpdateNamespace(context.Background(), req)
+}
+
+func TestNamespacePermissions(t *testing.T) {
+	tests := []struct {
+		name       string
+		req        func(other []byte) *pb.NamespaceRequest
+		wantCreate codes.Code
+		wantPut    codes.Code // Resultado de una subida del otro nodo al namespace.
+		wantGet    codes.Code // Resultado de una descarga del otro nodo de un archivo del dueño.
+	}{
+		{name: "público por defecto", req: func([]byte) *pb.NamespaceRequest { return &pb.NamespaceRequest{} }, wantPut: codes.PermissionDenied},
+		{name: "privado", req: func([]byte) *pb.NamespaceRequest { return &pb.NamespaceRequest{Visibility: VisibilityPrivate} }, wantPut: codes.PermissionDenied, wantGet: codes.PermissionDenied},
+		{name: "privado con lector", req: func(other []byte) *pb.NamespaceRequest {
+			return &pb.NamespaceRequest{Visibility: VisibilityPrivate, GrantRead: [][]byte{other}}
+		}, wantPut: codes.PermissionDenied},
+		{name: "privado con escritor", req: func(other []byte) *pb.NamespaceRequest {
+			return &pb.NamespaceRequest{Visibility: VisibilityPrivate, GrantWrite: [][]byte{other}}
+		}},
+		{name: "nombre con separador", req: func([]byte) *pb.NamespaceRequest { return &pb.NamespaceRequest{Name: "a:b"} }, wantCreate: codes.InvalidArgument},
+		{name: "cuota inválida", req: func([]byte) *pb.NamespaceRequest { return &pb.NamespaceRequest{QuotaMb: -2} }, wantCreate: codes.InvalidArgument},
+		{name: "clave inválida", req: func([]byte) *pb.NamespaceRequest { return &pb.NamespaceRequest{GrantWrite: [][]byte{[]byte("corta")}} }, wantCreate: codes.InvalidArgument},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, nodes := newTestNetwork(t, Config{Replicas: 1}, 3)
+			owner, other := nodes[0], nodes[1]
+			req := tt.req(other.identity.PublicKey())
+			if req.Name == "" {
+				req.Name = "equipo"
+			}
+			_, err := owner.updateNamespace(s, req)
+			if got := status.Code(err); got != tt.wantCreate {
+				t.Fatalf("UpdateNamespace: código %v, se esperaba %v (%v)", got, tt.wantCreate, err)
+			}
+			if err != nil {
+				return
+			}
+
+			if _, err := owner.join(s, &pb.JoinRequest{Action: "put", Namespace: "equipo", FileName: "archivo", FileSizeMb: 1}); err != nil {
+				t.Fatalf("put del dueño: %v", err)
+			}
+			_, err = other.join(s, &pb.JoinRequest{Action: "put", Namespace: "equipo", FileName: "propio", FileSizeMb: 1})
+			if got := status.Code(err); got != tt.wantPut {
+				t.Errorf("put del otro nodo: código %v, se esperaba %v (%v)", got, tt.wantPut, err)
+			}
+			if err == nil {
+				// El dueño del namespace puede modificar los archivos de otros
+				if _, err := owner.join(s, &pb.JoinRequest{Action: "put", Namespace: "equipo", FileName: "propio", FileSizeMb: 1}); err != nil {
+					t.Errorf("el dueño no pudo subir una versión del archivo de otro nodo: %v", err)
+				}
+			}
+			_, err = other.join(s, &pb.JoinRequest{Action: "get", Namespace: "equipo", FileName: "archivo"})
+			if got := status.Code(err); got != tt.wantGet {
+				t.Errorf("get del otro nodo: código %v, se esperaba %v (%v)", got, tt.wantGet, err)
+			}
+		})
+	}
+}
+
+func TestNamespaceIsolation(t *testing.T) {
+	s, nodes := newTestNetwork(t, Config{Replicas: 1}, 3)
+	owner, other := nodes[0], nodes[1]
+	if _, err := owner.updateNamespace(s, &pb.NamespaceRequest{Name: "equipo", Visibility: VisibilityPrivate}); err != nil {
+		t.Fatalf("UpdateNamespace: %v", err)
+	}
+
+	// Solo el dueño puede cambiar el namespace
+	if _, err := other.updateNamespace(s, &pb.NamespaceRequest{Name: "equipo", Visibility: VisibilityPublic}); status.Code(err) != codes.PermissionDenied {
+		t.Errorf("otro nodo cambió el namespace: se esperaba PermissionDenied y se obtuvo %v", err)
+	}
+	if _, err := other.join(s, &pb.JoinRequest{Action: "put", Namespace: "inexistente", FileName: "archivo", FileSizeMb: 1}); status.Code(err) != codes.NotFound {
+		t.Errorf("put en un namespace inexistente: se esperaba NotFound y se obtuvo %v", err)
+	}
+
+	// El mismo nombre en otro namespace es otro archivo, con sus propios permisos
+	if _, err := owner.join(s, &pb.JoinRequest{Action: "put", Namespace: "equipo", FileName: "archivo", FileSizeMb: 1}); err != nil {
+		t.Fatalf("put en el namespace: %v", err)
+	}
+	if _, err := other.join(s, &pb.JoinRequest{Action: "put", FileName: "archivo", FileSizeMb: 1}); err != nil {
+		t.Fatalf("put en el namespace por defecto: %v", err)
+	}
+	private, err := owner.join(s, &pb.JoinRequest{Action: "get", Namespace: "equipo", FileName: "archivo"})
+	if err != nil {
+		t.Fatalf("get en el namespace: %v", err)
+	}
+	public, err := owner.join(s, &pb.JoinRequest{Action: "get", FileName: "archivo"})
+	if err != nil {
+		t.Fatalf("get en el namespace por defecto: %v", err)
+	}
+	if private.FileId == public.FileId {
+		t.Errorf("los dos archivos comparten el id %s", private.FileId)
+	}
+	if _, err := other.join(s, &pb.JoinRequest{Action: "get", Namespace: "equipo", FileName: "archivo"}); status.Code(err) != codes.PermissionDenied {
+		t.Errorf("el otro nodo descargó el archivo privado: se esperaba PermissionDenied y se obtuvo %v", err)
+	}
+}
@@ -0,0 +1,180 @@
+package tracker
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"fmt"
+	"math/rand"
+	"sort"
+)
+
+// Nombres de las políticas de ubicación disponibles.
+const (
+	PlacementLeastLoaded    = "least-loaded"
+	PlacementWeighted       = "weighted"
+	PlacementRandom         = "random"
+	PlacementConsistentHash = "consistent-hash"
+)
+
+// Candidate describe un nodo elegible para almacenar una réplica.
+type Candidate struct {
+	NodeID        string
+	Chunks        int               // Chunks asignados actualmente al nodo.
+	CapacityBytes int64             // Capacidad reportada (0 = sin límite).
+	FreeBytes     int64             // Espacio libre estimado.
+	Labels        map[string]string // Dominios de falla del nodo ("host", "rack", "zone").
+}
+
+// PlacementPolicy decide en qué nodos se almacenan las réplicas de un chunk.
+type PlacementPolicy interface {
+	// Select devuelve hasta replicas nodos distintos de candidates para el chunk indicado.
+	Select(chunkID string, candidates []Candidate, replicas int) []string
+}
+
+// NewPlacementPolicy crea la política de ubicación con el nombre indicado.
+func NewPlacementPolicy(name string) (PlacementPolicy, error) {
+	switch name {
+	case PlacementLeastLoaded, "":
+		return leastLoadedPolicy{}, nil
+	case PlacementWeighted:
+		return weightedPolicy{}, nil
+	case PlacementRandom:
+		return randomPolicy{}, nil
+	case PlacementConsistentHash:
+		return consistentHashPolicy{virtualNodes: defaultVirtualNodes}, nil
+	}
+	return nil, fmt.Errorf("política de ubicación desconocida: %s", name)
+}
+
+// leastLoadedPolicy elige los nodos con menos chunks asignados.
+type leastLoadedPolicy struct{}
+
+func (leastLoadedPolicy) Select(chunkID string, candidates []Candidate, replicas int) []string {
+	sorted := append([]Candidate(nil), candidates...)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].Chunks < sorted[j].Chunks
+	})
+
+	var selectedNodes []string
+	for _, c := range sorted {
+		if len(selectedNodes) == replicas {
+			break
+		}
+		selectedNodes = append(selectedNodes, c.NodeID)
+	}
+	return selectedNodes
+}
+
+// chunkRand devuelve un generador pseudoaleatorio derivado del chunk, para que las políticas
+// al azar ubiquen igual el mismo chunk ante los mismos candidatos.
+func chunkRand(chunkID string) *rand.Rand {
+	return rand.New(rand.NewSource(int64(hashKey(chunkID))))
+}
+
+// weightedPolicy elige nodos al azar con probabilidad proporcional a su espacio libre.
+type weightedPolicy struct{}
+
+func (weightedPolicy) Select(chunkID string, candidates []Candidate, replicas int) []string {
+	// Los nodos sin límite pesan lo mismo que el nodo con más espacio libre
+	var maxFree int64 = chunkSizeBytes
+	for _, c := range candidates {
+		if c.CapacityBytes > 0 && c.FreeBytes > maxFree {
+			maxFree = c.FreeBytes
+		}
+	}
+
+	weights := make([]int64, len(candidates))
+	for i, c := range candidates {
+		weights[i] = c.FreeBytes
+		if c.CapacityBytes <= 0 {
+			weights[i] = maxFree
+		}
+		if weights[i] <= 0 {
+			weights[i] = 1
+		}
+	}
+
+	rng := chunkRand(chunkID)
+	var selectedNodes []string
+	for len(selectedNodes) < replicas {
+		var total int64
+		for _, w := range weights {
+			total += w
+		}
+		if total == 0 {
+			break
+		}
+
+		// Muestreo sin reemplazo: el peso del nodo elegido se anula
+		r := rng.Int63n(total)
+		for i, w := range weights {
+			if r < w {
+				selectedNodes = append(selectedNodes, candidates[i].NodeID)
+				weights[i] = 0
+				break
+			}
+			r -= w
+		}
+	}
+	return selectedNodes
+}
+
+// randomPolicy elige nodos al azar de manera uniforme.
+type randomPolicy struct{}
+
+func (randomPolicy) Select(chunkID string, candidates []Candidate, replicas int) []string {
+	var selectedNodes []string
+	for _, i := range chunkRand(chunkID).Perm(len(candidates)) {
+		if len(selectedNodes) == replicas {
+			break
+		}
+		selectedNodes = append(selectedNodes, candidates[i].NodeID)
+	}
+	return selectedNodes
+}
+
+// Cantidad de puntos que cada nodo ocupa en el anillo de hashing consistente.
+const defaultVirtualNodes = 100
+
+// consistentHashPolicy ubica cada chunk en los nodos que le siguen en un anillo de hashing,
+// de modo que agregar o quitar un nodo solo mueve una fracción de los chunks.
+type consistentHashPolicy struct {
+	virtualNodes int
+}
+
+// ringPoint es una posición del anillo asociada a un nodo.
+type ringPoint struct {
+	hash   uint64
+	nodeID string
+}
+
+func (p consistentHashPolicy) Select(chunkID string, candidates []Candidate, replicas int) []string {
+	var ring []ringPoint
+	for _, c := range candidates {
+		for v := 0; v < p.virtualNodes; v++ {
+			ring = append(ring, ringPoint{hash: hashKey(fmt.Sprintf("%s#%d", c.NodeID, v)), nodeID: c.NodeID})
+		}
+	}
+	if len(ring) == 0 {
+		return nil
+	}
+	sort.Slice(ring, func(i, j int) bool { return ring[i].hash < ring[j].hash })
+
+	// Recorrer el anillo en sentido horario desde la posición del chunk
+	start := sort.Search(len(ring), func(i int) bool { return ring[i].hash >= hashKey(chunkID) })
+
+	var selectedNodes []string
+	for i := 0; i < len(ring) && len(selectedNodes) < replicas; i++ {
+		nodeID := ring[(start+i)%len(ring)].nodeID
+		if !contains(selectedNodes, nodeID) {
+			selectedNodes = append(selectedNodes, nodeID)
+		}
+	}
+	return selectedNodes
+}
+
+// hashKey calcula la posición de una clave en el anillo.
+func hashKey(key string) uint64 {
+	sum := sha256.Sum256([]byte(key))
+	return binary.BigEndian.Uint64(sum[:8])
+}
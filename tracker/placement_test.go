@@ -0,0 +1,300 @@
+package tracker
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+)
+
+// allPolicies devuelve las cuatro políticas de ubicación por nombre.
+func allPolicies(t *testing.T) map[string]PlacementPolicy {
+	policies := make(map[string]PlacementPolicy)
+	for _, name := range []string{PlacementLeastLoaded, PlacementWeighted, PlacementRandom, PlacementConsistentHash} {
+		policy, err := NewPlacementPolicy(name)
+		if err != nil {
+			t.Fatalf("NewPlacementPolicy(%q): %v", name, err)
+		}
+		policies[name] = policy
+	}
+	return policies
+}
+
+// testCandidates crea n nodos vacíos con la misma capacidad, repartidos en zonas y racks.
+func testCandidates(n int) []Candidate {
+	candidates := make([]Candidate, n)
+	for i := range candidates {
+		candidates[i] = Candidate{
+			NodeID:        fmt.Sprintf("10.0.0.%d:50000", i+1),
+			CapacityBytes: 1 << 40,
+			FreeBytes:     1 << 40,
+			Labels: map[string]string{
+				"zone": fmt.Sprintf("z%d", i%3),
+				"rack": fmt.Sprintf("r%d", i%6),
+			},
+		}
+	}
+	return candidates
+}
+
+func TestPlacementSelectsDistinctNodes(t *testing.T) {
+	tests := []struct {
+		candidates int
+		replicas   int
+		want       int
+	}{
+		{candidates: 5, replicas: 1, want: 1},
+		{candidates: 5, replicas: 3, want: 3},
+		{candidates: 5, replicas: 5, want: 5},
+		{candidates: 3, replicas: 5, want: 3},
+		{candidates: 0, replicas: 3, want: 0},
+	}
+	for name, policy := range allPolicies(t) {
+		for _, tt := range tests {
+			t.Run(fmt.Sprintf("%s/%d-de-%d", name, tt.replicas, tt.candidates), func(t *testing.T) {
+				candidates := testCandidates(tt.candidates)
+				valid := make(map[string]bool)
+				for _, c := range candidates {
+					valid[c.NodeID] = true
+				}
+
+				selected := policy.Select("archivo-1", candidates, tt.replicas)
+				if len(selected) != tt.want {
+					t.Fatalf("se eligieron %d nodos, se esperaban %d: %v", len(selected), tt.want, selected)
+				}
+				seen := make(map[string]bool)
+				for _, node := range selected {
+					if !valid[node] {
+						t.Errorf("se eligió el nodo %s, que no es candidato", node)
+					}
+					if seen[node] {
+						t.Errorf("el nodo %s se eligió dos veces", node)
+					}
+					seen[node] = true
+				}
+			})
+		}
+	}
+}
+
+func TestPlacementIsDeterministic(t *testing.T) {
+	candidates := testCandidates(8)
+	for i := range candidates {
+		candidates[i].Chunks = i % 3
+		candidates[i].FreeBytes = int64(i+1) << 30
+	}
+	for name, policy := range allPolicies(t) {
+		t.Run(name, func(t *testing.T) {
+			for i := 0; i < 50; i++ {
+				chunkID := fmt.Sprintf("archivo-%d", i)
+				first := policy.Select(chunkID, candidates, 3)
+				second := policy.Select(chunkID, append([]Candidate(nil), candidates...), 3)
+				if !reflect.DeepEqual(first, second) {
+					t.Fatalf("el chunk %s se ubicó en %v y luego en %v", chunkID, first, second)
+				}
+			}
+		})
+	}
+}
+
+func TestPlacementSpreadsChunksEvenly(t *testing.T) {
+	const (
+		nodes    = 5
+		chunks   = 2000
+		replicas = 2
+	)
+	// Diferencia máxima admitida entre la carga de un nodo y la carga media
+	tolerance := map[string]float64{
+		PlacementLeastLoaded:    0.01,
+		PlacementWeighted:       0.10,
+		PlacementRandom:         0.10,
+		PlacementConsistentHash: 0.25,
+	}
+	for name, policy := range allPolicies(t) {
+		t.Run(name, func(t *testing.T) {
+			candidates := testCandidates(nodes)
+			load := make(map[string]int)
+			for i := 0; i < chunks; i++ {
+				// Actualizar la carga y el espacio libre como lo hace el tracker al asignar chunks
+				for j := range candidates {
+					candidates[j].Chunks = load[candidates[j].NodeID]
+					candidates[j].FreeBytes = candidates[j].CapacityBytes - int64(candidates[j].Chunks)*chunkSizeBytes
+				}
+				for _, node := range policy.Select(fmt.Sprintf("archivo-%d", i), candidates, replicas) {
+					load[node]++
+				}
+			}
+
+			mean := float64(chunks*replicas) / nodes
+			for _, c := range candidates {
+				deviation := (float64(load[c.NodeID]) - mean) / mean
+				if deviation > tolerance[name] || deviation < -tolerance[name] {
+					t.Errorf("el nodo %s recibió %d réplicas; la media es %.0f (desvío %.1f%%)", c.NodeID, load[c.NodeID], mean, deviation*100)
+				}
+			}
+		})
+	}
+}
+
+func TestWeightedPolicyPrefersFreeSpace(t *testing.T) {
+	candidates := testCandidates(2)
+	candidates[0].FreeBytes = 9 << 30
+	candidates[1].FreeBytes = 1 << 30
+
+	load := make(map[string]int)
+	for i := 0; i < 1000; i++ {
+		load[weightedPolicy{}.Select(fmt.Sprintf("archivo-%d", i), candidates, 1)[0]]++
+	}
+	if share := float64(load[candidates[0].NodeID]) / 1000; share < 0.85 || share > 0.95 {
+		t.Errorf("el nodo con el 90%% del espacio libre recibió el %.1f%% de los chunks", share*100)
+	}
+}
+
+func TestPlacementSpreadsAcrossDomains(t *testing.T) {
+	tests := []struct {
+		name     string
+		replicas int
+		placed   []Candidate
+		level    string
+		want     int // Dominios distintos esperados entre las réplicas elegidas.
+	}{
+		{name: "zonas", replicas: 3, level: "zone", want: 3},
+		{name: "racks", replicas: 6, level: "rack", want: 6},
+		{name: "más réplicas que zonas", replicas: 4, level: "zone", want: 3},
+		{
+			name:     "zona ocupada",
+			replicas: 2,
+			placed:   []Candidate{{NodeID: "10.0.1.1:50000", Labels: map[string]string{"zone": "z0"}}},
+			level:    "zone",
+			want:     2,
+		},
+	}
+	for name, policy := range allPolicies(t) {
+		for _, tt := range tests {
+			t.Run(name+"/"+tt.name, func(t *testing.T) {
+				candidates := testCandidates(12)
+				byID := make(map[string]Candidate)
+				for _, c := range candidates {
+					byID[c.NodeID] = c
+				}
+
+				for i := 0; i < 20; i++ {
+					var ordered []Candidate
+					for _, node := range policy.Select(fmt.Sprintf("archivo-%d", i), candidates, len(candidates)) {
+						ordered = append(ordered, byID[node])
+					}
+					selected := spreadAcrossDomains(ordered, tt.replicas, tt.placed)
+					if len(selected) != tt.replicas {
+						t.Fatalf("se eligieron %d nodos, se esperaban %d", len(selected), tt.replicas)
+					}
+
+					domains := make(map[string]bool)
+					for _, node := range selected {
+						domains[domainKey(node, byID[node].Labels, tt.level)] = true
+					}
+					for _, c := range tt.placed {
+						if domains[domainKey(c.NodeID, c.Labels, tt.level)] {
+							t.Errorf("se eligió un nodo del dominio ocupado %s", domainKey(c.NodeID, c.Labels, tt.level))
+						}
+					}
+					if len(domains) != tt.want {
+						t.Errorf("las réplicas %v ocupan %d dominios de nivel %s, se esperaban %d", selected, len(domains), tt.level, tt.want)
+					}
+				}
+			})
+		}
+	}
+}
+
+func TestSpreadWithoutDomainLabels(t *testing.T) {
+	tests := []struct {
+		name    string
+		ordered []Candidate
+		want    []string
+	}{
+		{
+			name: "sin etiquetas",
+			ordered: []Candidate{
+				{NodeID: "10.0.0.1:50001"},
+				{NodeID: "10.0.0.1:50002"},
+				{NodeID: "10.0.0.2:50001"},
+			},
+			want: []string{"10.0.0.1:50001", "10.0.0.2:50001"},
+		},
+		{
+			name: "solo zona",
+			ordered: []Candidate{
+				{NodeID: "10.0.0.1:50001", Labels: map[string]string{"zone": "z0"}},
+				{NodeID: "10.0.0.1:50002", Labels: map[string]string{"zone": "z0"}},
+				{NodeID: "10.0.0.2:50001", Labels: map[string]string{"zone": "z0"}},
+			},
+			want: []string{"10.0.0.1:50001", "10.0.0.2:50001"},
+		},
+		{
+			name: "zona nueva antes que host nuevo",
+			ordered: []Candidate{
+				{NodeID: "10.0.0.1:50001", Labels: map[string]string{"zone": "z0"}},
+				{NodeID: "10.0.0.2:50001", Labels: map[string]string{"zone": "z0"}},
+				{NodeID: "10.0.0.3:50001", Labels: map[string]string{"zone": "z1"}},
+			},
+			want: []string{"10.0.0.1:50001", "10.0.0.3:50001"},
+		},
+		{
+			name: "zona sin rack",
+			ordered: []Candidate{
+				{NodeID: "10.0.0.1:50001", Labels: map[string]string{"zone": "z0", "host": "h1"}},
+				{NodeID: "10.0.0.2:50001", Labels: map[string]string{"zone": "z0", "host": "h1"}},
+				{NodeID: "10.0.0.3:50001", Labels: map[string]string{"zone": "z0", "host": "h2"}},
+			},
+			want: []string{"10.0.0.1:50001", "10.0.0.3:50001"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := spreadAcrossDomains(tt.ordered, len(tt.want), nil)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("spreadAcrossDomains = %v, se esperaba %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSelectNodesSkipsFullNodes(t *testing.T) {
+	tests := []struct {
+		name     string
+		free     []int64 // Espacio libre de cada nodo; todos tienen capacidad limitada.
+		replicas int
+		want     []int // Nodos que se pueden elegir.
+	}{
+		{name: "sin nodos llenos", free: []int64{chunkSizeBytes, 2 * chunkSizeBytes, chunkSizeBytes}, replicas: 3, want: []int{0, 1, 2}},
+		{name: "un nodo lleno", free: []int64{chunkSizeBytes, 0, 5 * chunkSizeBytes}, replicas: 3, want: []int{0, 2}},
+		{name: "espacio menor a un chunk", free: []int64{chunkSizeBytes - 1, chunkSizeBytes, 0}, replicas: 2, want: []int{1}},
+		{name: "todos llenos", free: []int64{0, 0, 0}, replicas: 2, want: nil},
+	}
+	for name, policy := range allPolicies(t) {
+		for _, tt := range tests {
+			t.Run(name+"/"+tt.name, func(t *testing.T) {
+				s := NewTrackerServer(Config{Placement: policy})
+				allowed := make(map[string]bool)
+				for i, free := range tt.free {
+					node := fmt.Sprintf("10.0.0.%d:50000", i+1)
+					s.nodes[node] = &nodeInfo{capacityBytes: 10 * chunkSizeBytes, freeBytes: free}
+					for _, j := range tt.want {
+						if j == i {
+							allowed[node] = true
+						}
+					}
+				}
+
+				selected := s.selectNodesForChunk("archivo-1", tt.replicas, nil)
+				if len(selected) != min(tt.replicas, len(tt.want)) {
+					t.Fatalf("se eligieron %v, se esperaban %d nodos", selected, min(tt.replicas, len(tt.want)))
+				}
+				for _, node := range selected {
+					if !allowed[node] {
+						t.Errorf("se eligió el nodo %s, que no tiene espacio para el chunk", node)
+					}
+				}
+			})
+		}
+	}
+}
@@ -0,0 +1,161 @@
+package tracker
+
+import (
+	"context"
+	"crypto/ed25519"
+	"sort"
+	"time"
+
+	pb "P2P_BitTorrent/pb"
+	"P2P_BitTorrent/security"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Período por defecto de la cuota de descarga.
+const DefaultQuotaPeriod = 24 * time.Hour
+
+// downloadWindow cuenta los bytes que una clave descargó desde el inicio de su período.
+type downloadWindow struct {
+	start time.Time
+	bytes int64
+}
+
+// storedBy devuelve los bytes de todas las versiones subidas por una clave y su desglose
+// por namespace. Se cuenta el tamaño de los archivos, no el de sus réplicas.
+func (s *trackerServer) storedBy(key ed25519.PublicKey) (int64, map[string]*pb.NamespaceUsage) {
+	var total int64
+	usage := make(map[string]*pb.NamespaceUsage)
+	for fileName, versions := range s.files {
+		counted := false
+		for _, file := range versions {
+			if file.uploaderKey != string(key) {
+				continue
+			}
+			ns, exists := usage[fileName.namespace]
+			if !exists {
+				ns = &pb.NamespaceUsage{Namespace: fileName.namespace}
+				usage[fileName.namespace] = ns
+			}
+			if !counted {
+				ns.Files++
+				counted = true
+			}
+			ns.StoredBytes += int64(file.sizeMb) << 20
+			total += int64(file.sizeMb) << 20
+		}
+	}
+	return total, usage
+}
+
+// checkStorageQuota verifica que una subida de size bytes no supere la cuota del namespace
+// ni la cuota de almacenamiento del nodo que la hace.
+func (s *trackerServer) checkStorageQuota(ns *namespace, uploader ed25519.PublicKey, size int64) error {
+	if ns != nil && ns.quotaBytes > 0 {
+		used, _ := s.namespaceUsage(ns.name)
+		if used+size > ns.quotaBytes {
+			return status.Errorf(codes.ResourceExhausted, "el namespace %s no tiene espacio: usa %d de %d bytes y el archivo ocupa %d bytes",
+				ns.name, used, ns.quotaBytes, size)
+		}
+	}
+
+	if s.storageQuota > 0 {
+		used, _ := s.storedBy(uploader)
+		if used+size > s.storageQuota {
+			return status.Errorf(codes.ResourceExhausted, "cuota de almacenamiento superada: el nodo usa %d de %d bytes y el archivo ocupa %d bytes",
+				used, s.storageQuota, size)
+		}
+	}
+	return nil
+}
+
+// currentWindow devuelve el período de descarga actual de una clave, empezando uno nuevo
+// si el anterior terminó.
+func (s *trackerServer) currentWindow(key ed25519.PublicKey) *downloadWindow {
+	window, exists := s.downloads[string(key)]
+	if !exists || time.Since(window.start) >= s.quotaPeriod {
+		window = &downloadWindow{start: time.Now()}
+		s.downloads[string(key)] = window
+	}
+	return window
+}
+
+// chargeDownload cuenta una descarga de size bytes a la clave que la pide, o la rechaza si
+// supera la cuota de descarga del período. El tracker no ve las transferencias entre nodos:
+// cobra el tamaño del archivo al entregar el permiso para pedir sus chunks.
+func (s *trackerServer) chargeDownload(key ed25519.PublicKey, size int64) error {
+	window := s.currentWindow(key)
+	if s.downloadQuota > 0 && window.bytes+size > s.downloadQuota {
+		return status.Errorf(codes.ResourceExhausted, "cuota de descarga superada: el nodo descargó %d de %d bytes y el archivo ocupa %d bytes; el período termina el %s",
+			window.bytes, s.downloadQuota, size, window.start.Add(s.quotaPeriod).Format("2006-01-02 15:04:05"))
+	}
+	window.bytes += size
+	return nil
+}
+
+// downloadChunks calcula qué se cobra por descargar un archivo: sin selecciones, o si el
+// archivo no es una colección o usa erasure coding (se descarga completo), todo su tamaño;
+// con selecciones, solo los chunks de datos que cubren los archivos elegidos, cuyos índices
+// también devuelve para limitar el permiso de lectura a ellos.
+func downloadChunks(file *fileRecord, only []string) ([]int32, int64, error) {
+	full := int64(file.sizeMb) << 20
+	if len(only) == 0 || len(file.manifest.GetEntries()) == 0 || file.storageMode == StorageErasure {
+		return nil, full, nil
+	}
+
+	entries, err := security.SelectEntries(file.manifest, only)
+	if err != nil {
+		return nil, 0, status.Error(codes.InvalidArgument, err.Error())
+	}
+	if len(entries) == len(file.manifest.Entries) {
+		return nil, full, nil
+	}
+
+	chunks := security.CollectionChunks(file.manifest, entries)
+	var size int64
+	for _, index := range chunks {
+		if int(index) <= len(file.manifest.StoredChunks) {
+			size += file.manifest.StoredChunks[index-1].Size
+		} else {
+			size += file.manifest.ChunkSize
+		}
+	}
+	return chunks, size, nil
+}
+
+// GetUsage devuelve el espacio que ocupan los archivos subidos por el nodo, lo que descargó
+// en el período actual y las cuotas que se le aplican.
+func (s *trackerServer) GetUsage(ctx context.Context, req *pb.UsageRequest) (*pb.UsageResponse, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	info, err := s.authenticate(ctx, req.NodeId, req)
+	if err != nil {
+		return nil, err
+	}
+	if info == nil {
+		return nil, status.Errorf(codes.PermissionDenied, "nodo %s no registrado en la red", req.NodeId)
+	}
+
+	stored, usage := s.storedBy(info.publicKey)
+	window := s.currentWindow(info.publicKey)
+	res := &pb.UsageResponse{
+		StoredBytes:        stored,
+		StorageQuotaBytes:  s.storageQuota,
+		DownloadedBytes:    window.bytes,
+		DownloadQuotaBytes: s.downloadQuota,
+		PeriodEndsAt:       window.start.Add(s.quotaPeriod).Unix(),
+	}
+	for name, ns := range usage {
+		ns.NamespaceUsedBytes, _ = s.namespaceUsage(name)
+		if config, exists := s.namespaces[name]; exists {
+			ns.NamespaceQuotaBytes = config.quotaBytes
+		}
+		res.Namespaces = append(res.Namespaces, ns)
+	}
+	sort.Slice(res.Namespaces, func(i, j int) bool {
+		return res.Namespaces[i].Namespace < res.Namespaces[j].Namespace
+	})
+	return res, nil
+}
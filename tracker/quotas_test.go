@@ -0,0 +1,199 @@
+package tracker
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"testing"
+
+	pb "P2P_BitTorrent/pb"
+	"P2P_BitTorrent/security"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+// testNode es un nodo registrado en un tracker de prueba.
+type testNode struct {
+	id       string
+	identity *security.Identity
+}
+
+// newTestNode crea la identidad de un nodo y lo registra en el tracker con espacio libre.
+func newTestNode(t *testing.T, s *trackerServer, id string) *testNode {
+	t.Helper()
+	identity, err := security.NewIdentity()
+	if err != nil {
+		t.Fatalf("NewIdentity: %v", err)
+	}
+	n := &testNode{id: id, identity: identity}
+	if _, err := n.join(s, &pb.JoinRequest{Action: "noop"}); err != nil {
+		t.Fatalf("no se pudo registrar el nodo %s: %v", id, err)
+	}
+	return n
+}
+
+// join firma y envía una solicitud de JoinNetwork en nombre del nodo.
+func (n *testNode) join(s *trackerServer, req *pb.JoinRequest) (*pb.JoinResponse, error) {
+	req.NodeId = n.id
+	if req.CapacityBytes == 0 {
+		req.CapacityBytes, req.FreeBytes = 1<<40, 1<<40
+	}
+	if err := n.identity.Sign(req); err != nil {
+		return nil, err
+	}
+	return s.JoinNetwork(context.Background(), req)
+}
+
+// newTestNetwork crea un tracker con count nodos registrados.
+func newTestNetwork(t *testing.T, cfg Config, count int) (*trackerServer, []*testNode) {
+	t.Helper()
+	s := NewTrackerServer(cfg)
+	nodes := make([]*testNode, count)
+	for i := range nodes {
+		nodes[i] = newTestNode(t, s, fmt.Sprintf("10.0.0.%d:50000", i+1))
+	}
+	return s, nodes
+}
+
+func TestPutRejectsInvalidSizes(t *testing.T) {
+	s, nodes := newTestNetwork(t, Config{Replicas: 1, StorageQuota: 4 << 20}, 2)
+	uploader := nodes[0]
+
+	for _, size := range []int32{0, -1, -2048} {
+		_, err := uploader.join(s, &pb.JoinRequest{Action: "put", FileName: fmt.Sprintf("archivo%d", size), FileSizeMb: size})
+		if status.Code(err) != codes.InvalidArgument {
+			t.Errorf("put de %d MB: se esperaba InvalidArgument y se obtuvo %v", size, err)
+		}
+	}
+
+	// Las subidas rechazadas no liberan cuota: 4 MB siguen entrando y 1 MB más no
+	if _, err := uploader.join(s, &pb.JoinRequest{Action: "put", FileName: "lleno", FileSizeMb: 4}); err != nil {
+		t.Fatalf("put dentro de la cuota: %v", err)
+	}
+	if _, err := uploader.join(s, &pb.JoinRequest{Action: "put", FileName: "extra", FileSizeMb: 1}); status.Code(err) != codes.ResourceExhausted {
+		t.Errorf("put por encima de la cuota: se esperaba ResourceExhausted y se obtuvo %v", err)
+	}
+}
+
+func TestStorageQuotas(t *testing.T) {
+	tests := []struct {
+		name      string
+		nodeQuota int64 // Cuota de almacenamiento por nodo, en MB.
+		nsQuota   int64 // Cuota del namespace, en MB.
+		namespace string
+		sizes     []int32 // Subidas en orden, en MB.
+		want      []codes.Code
+	}{
+		{name: "sin cuota", sizes: []int32{100, 100}, want: []codes.Code{codes.OK, codes.OK}},
+		{name: "cuota del nodo", nodeQuota: 5, sizes: []int32{3, 2, 1}, want: []codes.Code{codes.OK, codes.OK, codes.ResourceExhausted}},
+		{name: "subida más grande que la cuota", nodeQuota: 5, sizes: []int32{6, 5}, want: []codes.Code{codes.ResourceExhausted, codes.OK}},
+		{name: "cuota del namespace", nsQuota: 3, namespace: "equipo", sizes: []int32{2, 2, 1}, want: []codes.Code{codes.OK, codes.ResourceExhausted, codes.OK}},
+		{name: "la cuota del nodo no cuenta el namespace por separado", nodeQuota: 3, nsQuota: 10, namespace: "equipo", sizes: []int32{2, 2}, want: []codes.Code{codes.OK, codes.ResourceExhausted}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, nodes := newTestNetwork(t, Config{Replicas: 1, StorageQuota: tt.nodeQuota << 20}, 2)
+			uploader := nodes[0]
+			if tt.namespace != "" {
+				req := &pb.NamespaceRequest{NodeId: uploader.id, Name: tt.namespace, QuotaMb: tt.nsQuota}
+				if err := uploader.identity.Sign(req); err != nil {
+					t.Fatalf("Sign: %v", err)
+				}
+				if _, err := s.UpdateNamespace(context.Background(), req); err != nil {
+					t.Fatalf("UpdateNamespace: %v", err)
+				}
+			}
+
+			for i, size := range tt.sizes {
+				_, err := uploader.join(s, &pb.JoinRequest{Action: "put", Namespace: tt.namespace, FileName: fmt.Sprintf("archivo%d", i), FileSizeMb: size})
+				if got := status.Code(err); got != tt.want[i] {
+					t.Errorf("subida %d de %d MB: código %v, se esperaba %v (%v)", i, size, got, tt.want[i], err)
+				}
+			}
+		})
+	}
+}
+
+func TestDownloadQuota(t *testing.T) {
+	s, nodes := newTestNetwork(t, Config{Replicas: 1, DownloadQuota: 5 << 20}, 2)
+	if _, err := nodes[0].join(s, &pb.JoinRequest{Action: "put", FileName: "archivo", FileSizeMb: 2}); err != nil {
+		t.Fatalf("put: %v", err)
+	}
+
+	downloader := nodes[1]
+	want := []codes.Code{codes.OK, codes.OK, codes.ResourceExhausted}
+	for i, code := range want {
+		_, err := downloader.join(s, &pb.JoinRequest{Action: "get", FileName: "archivo"})
+		if got := status.Code(err); got != code {
+			t.Errorf("descarga %d: código %v, se esperaba %v (%v)", i, got, code, err)
+		}
+	}
+}
+
+func TestDownloadChunks(t *testing.T) {
+	// Colección de 45 bytes en chunks de 10: a.txt ocupa el chunk 1, docs/b.md del 1 al 3
+	// y e.bin el 4 y el 5, que mide 5 bytes
+	manifest := &pb.Manifest{
+		FileSize:    45,
+		ChunkSize:   10,
+		ChunkHashes: make([][]byte, 5),
+		Entries: []*pb.CollectionEntry{
+			{Path: "a.txt", Offset: 0, Size: 5},
+			{Path: "docs/b.md", Offset: 5, Size: 25},
+			{Path: "e.bin", Offset: 30, Size: 15},
+		},
+	}
+	for _, size := range []int64{10, 10, 10, 10, 5} {
+		manifest.StoredChunks = append(manifest.StoredChunks, &pb.ChunkDigest{Size: size})
+	}
+	full := int64(1 << 20)
+
+	tests := []struct {
+		name        string
+		only        []string
+		erasure     bool
+		noEntries   bool // El archivo no es una colección.
+		noDigests   bool // El manifiesto no declara el tamaño de cada chunk.
+		wantChunks  []int32
+		wantSize    int64
+		wantErrCode codes.Code
+	}{
+		{name: "sin selecciones", wantSize: full},
+		{name: "un archivo", only: []string{"a.txt"}, wantChunks: []int32{1}, wantSize: 10},
+		{name: "último chunk más corto", only: []string{"e.bin"}, wantChunks: []int32{4, 5}, wantSize: 15},
+		{name: "chunks compartidos se cobran una vez", only: []string{"a.txt", "docs"}, wantChunks: []int32{1, 2, 3}, wantSize: 30},
+		{name: "todos los archivos", only: []string{"a.txt", "docs", "e.bin"}, wantSize: full},
+		{name: "sin tamaños por chunk", only: []string{"e.bin"}, noDigests: true, wantChunks: []int32{4, 5}, wantSize: 20},
+		{name: "no es una colección", only: []string{"a.txt"}, noEntries: true, wantSize: full},
+		{name: "erasure coding", only: []string{"a.txt"}, erasure: true, wantSize: full},
+		{name: "selección sin coincidencias", only: []string{"nada"}, wantErrCode: codes.InvalidArgument},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := proto.Clone(manifest).(*pb.Manifest)
+			if tt.noEntries {
+				m.Entries = nil
+			}
+			if tt.noDigests {
+				m.StoredChunks = nil
+			}
+			file := &fileRecord{sizeMb: 1, storageMode: StorageReplication, manifest: m}
+			if tt.erasure {
+				file.storageMode = StorageErasure
+			}
+
+			chunks, size, err := downloadChunks(file, tt.only)
+			if got := status.Code(err); got != tt.wantErrCode {
+				t.Fatalf("downloadChunks: código %v, se esperaba %v (%v)", got, tt.wantErrCode, err)
+			}
+			if !slices.Equal(chunks, tt.wantChunks) {
+				t.Errorf("chunks %v, se esperaba %v", chunks, tt.wantChunks)
+			}
+			if size != tt.wantSize {
+				t.Errorf("se cobran %d bytes, se esperaba %d", size, tt.wantSize)
+			}
+		})
+	}
+}
@@ -0,0 +1,370 @@
+package tracker
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	pb "P2P_BitTorrent/pb"
+	"P2P_BitTorrent/security"
+
+	"google.golang.org/grpc"
+)
+
+// Intervalo entre revisiones de chunks con menos réplicas de las deseadas.
+const RepairInterval = 30 * time.Second
+
+// Clases de durabilidad y el factor de replicación de cada una.
+var durabilityClasses = map[string]int{
+	"reduced":  2,
+	"standard": DefaultReplicas,
+	"high":     5,
+}
+
+// replicationFor resuelve el factor de replicación de un archivo a partir del valor
+// explícito, la clase de durabilidad o el valor por defecto del tracker. Si se indican
+// ambos deben coincidir, y el factor no puede superar el máximo del tracker.
+func (s *trackerServer) replicationFor(replicas int32, durability string) (int, error) {
+	if replicas < 0 {
+		return 0, fmt.Errorf("factor de replicación inválido: %d", replicas)
+	}
+	n := s.replicas
+	if durability != "" {
+		class, exists := durabilityClasses[durability]
+		if !exists {
+			return 0, fmt.Errorf("clase de durabilidad desconocida: %s", durability)
+		}
+		if replicas > 0 && int(replicas) != class {
+			return 0, fmt.Errorf("la clase de durabilidad %s usa %d réplicas y se pidieron %d", durability, class, replicas)
+		}
+		n = class
+	}
+	if replicas > 0 {
+		n = int(replicas)
+	}
+	if n > s.maxReplicas {
+		return 0, fmt.Errorf("se pidieron %d réplicas y el tracker acepta como máximo %d", n, s.maxReplicas)
+	}
+	return n, nil
+}
+
+// newFileRecord valida las opciones de almacenamiento de un put y crea el registro del archivo.
+func (s *trackerServer) newFileRecord(req *pb.JoinRequest) (*fileRecord, error) {
+	if req.FileSizeMb <= 0 {
+		return nil, fmt.Errorf("tamaño de archivo inválido: %d MB", req.FileSizeMb)
+	}
+	file := &fileRecord{id: newFileID(), name: req.FileName, namespace: req.Namespace, sizeMb: req.FileSizeMb, durability: req.Durability, uploader: req.NodeId}
+
+	switch req.StorageMode {
+	case StorageReplication, "":
+		replicas, err := s.replicationFor(req.Replicas, req.Durability)
+		if err != nil {
+			return nil, err
+		}
+		file.storageMode = StorageReplication
+		file.replicas = replicas
+
+	case StorageErasure:
+		file.storageMode = StorageErasure
+		file.replicas = 1 // Cada shard se guarda una sola vez; la redundancia la da la paridad
+		file.dataShards, file.parityShards = req.DataShards, req.ParityShards
+		if file.dataShards == 0 {
+			file.dataShards = DefaultDataShards
+		}
+		if file.parityShards == 0 {
+			file.parityShards = DefaultParityShards
+		}
+		if file.dataShards < 0 || file.parityShards < 0 || file.dataShards+file.parityShards > 255 {
+			return nil, fmt.Errorf("tamaño de franja inválido: %d+%d", file.dataShards, file.parityShards)
+		}
+
+	default:
+		return nil, fmt.Errorf("modo de almacenamiento desconocido: %s", req.StorageMode)
+	}
+
+	return file, nil
+}
+
+// repairTask describe las copias que hacen falta para un chunk.
+type repairTask struct {
+	key     chunkKey
+	sources []string // Nodos que tienen el chunk.
+	targets []string // Nodos que deben recibir una copia.
+}
+
+// stripeTask es la reconstrucción de los shards perdidos de una franja de erasure coding.
+type stripeTask struct {
+	rebuilder string                   // Nodo con un shard de la franja que reconstruye los perdidos.
+	keys      map[string]chunkKey      // Shards perdidos, por chunk_id.
+	req       *pb.RebuildShardsRequest // Solicitud, sin los permisos.
+}
+
+// StartRepairLoop revisa periódicamente los chunks con menos réplicas de las que pide su archivo.
+func (s *trackerServer) StartRepairLoop(interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for range ticker.C {
+		s.repairUnderReplicated()
+	}
+}
+
+// repairUnderReplicated completa las réplicas que faltan copiando cada chunk desde un nodo que lo tiene.
+func (s *trackerServer) repairUnderReplicated() {
+	s.repairMu.Lock()
+	defer s.repairMu.Unlock()
+
+	s.mu.Lock()
+	var tasks []repairTask
+	var stripes []stripeTask
+	for _, file := range s.allVersions() {
+		// Un shard perdido no tiene de dónde copiarse: se reconstruye a partir de su franja
+		if file.storageMode == StorageErasure {
+			stripes = append(stripes, s.planStripeRepairs(file)...)
+			continue
+		}
+		for _, chunk := range file.chunks {
+			holders := s.fileChunks[chunk.key]
+			missing := file.replicas - len(holders)
+			if len(holders) == 0 || missing <= 0 {
+				continue
+			}
+			targets := s.selectNodesForChunk(chunk.key.String(), missing, holders)
+			if len(targets) > 0 {
+				tasks = append(tasks, repairTask{key: chunk.key, sources: append([]string(nil), holders...), targets: targets})
+			}
+		}
+	}
+	s.mu.Unlock()
+
+	// Las copias se hacen sin el lock para no bloquear al tracker mientras se contacta a los nodos
+	for _, task := range tasks {
+		s.replicate(task)
+	}
+	for _, task := range stripes {
+		s.rebuildStripe(task)
+	}
+}
+
+// planStripeRepairs arma la reconstrucción de cada franja del archivo que perdió shards y
+// conserva suficientes para recuperarlos: tantos como chunks de datos tiene la franja. Los
+// shards nuevos van a nodos que no tienen otro shard de la misma franja. Solo se reparan
+// archivos con manifiesto, porque sin él no se pueden verificar los shards reconstruidos.
+// Debe llamarse con s.mu tomado.
+func (s *trackerServer) planStripeRepairs(file *fileRecord) []stripeTask {
+	if file.manifest == nil {
+		return nil
+	}
+	byStripe := make(map[int32][]chunkRef)
+	byIndex := make(map[int32]chunkRef)
+	for _, chunk := range file.chunks {
+		byStripe[chunk.stripe] = append(byStripe[chunk.stripe], chunk)
+		byIndex[chunk.key.index] = chunk
+	}
+
+	var tasks []stripeTask
+	for stripe, chunks := range byStripe {
+		var lost []chunkRef
+		var holders []string
+		live, dataShards := 0, 0
+		for _, chunk := range chunks {
+			if chunk.shard < file.dataShards {
+				dataShards++
+			}
+			if nodes := s.fileChunks[chunk.key]; len(nodes) > 0 {
+				live++
+				holders = append(holders, nodes...)
+			} else {
+				lost = append(lost, chunk)
+			}
+		}
+		if len(lost) == 0 || live < dataShards {
+			continue
+		}
+
+		task := stripeTask{
+			rebuilder: holders[0],
+			keys:      make(map[string]chunkKey),
+			req: &pb.RebuildShardsRequest{
+				FileId:       file.id,
+				DataShards:   file.dataShards,
+				ParityShards: file.parityShards,
+				Targets:      make(map[string]string),
+				Manifest:     file.manifest,
+			},
+		}
+		exclude := append([]string(nil), holders...)
+		for _, chunk := range lost {
+			targets := s.selectNodesForChunk(chunk.key.String(), 1, exclude)
+			if len(targets) == 0 {
+				continue
+			}
+			task.keys[chunk.key.String()] = chunk.key
+			task.req.Targets[chunk.key.String()] = targets[0]
+			exclude = append(exclude, targets[0])
+		}
+		if len(task.keys) == 0 {
+			continue
+		}
+
+		for _, chunk := range chunks {
+			task.req.Shards = append(task.req.Shards, s.chunkInfo(chunk))
+		}
+		// Los chunks de datos vecinos de los perdidos, fuera de la franja, aportan sus pruebas de Merkle
+		added := make(map[int32]bool)
+		for _, chunk := range lost {
+			if chunk.shard >= file.dataShards {
+				continue
+			}
+			for _, index := range []int32{chunk.key.index - 1, chunk.key.index + 1} {
+				neighbor, exists := byIndex[index]
+				if !exists || added[index] || neighbor.stripe == stripe || neighbor.shard >= file.dataShards || len(s.fileChunks[neighbor.key]) == 0 {
+					continue
+				}
+				added[index] = true
+				task.req.Neighbors = append(task.req.Neighbors, s.chunkInfo(neighbor))
+			}
+		}
+		tasks = append(tasks, task)
+	}
+	return tasks
+}
+
+// chunkInfo describe un chunk con los nodos que lo tienen. Debe llamarse con s.mu tomado.
+func (s *trackerServer) chunkInfo(chunk chunkRef) *pb.ChunkInfo {
+	return &pb.ChunkInfo{
+		Key:    &pb.ChunkKey{FileId: chunk.key.fileID, Index: chunk.key.index},
+		Nodes:  append([]string(nil), s.fileChunks[chunk.key]...),
+		Stripe: chunk.stripe,
+		Shard:  chunk.shard,
+	}
+}
+
+// rebuildStripe pide a un nodo de la franja que reconstruya sus shards perdidos y registra
+// los nodos que los guardaron.
+func (s *trackerServer) rebuildStripe(task stripeTask) {
+	stored, err := s.requestRebuild(task.rebuilder, task.req)
+	if err != nil {
+		log.Printf("Error al reconstruir shards del archivo %s desde %s: %v", task.req.FileId, task.rebuilder, err)
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	_, deleted := s.tombstones[task.req.FileId]
+	for chunkID, node := range stored {
+		key, requested := task.keys[chunkID]
+		if !requested || task.req.Targets[chunkID] != node {
+			continue
+		}
+		if deleted {
+			// El archivo se eliminó mientras se reconstruía: los shards nuevos son basura
+			s.garbage[node] = append(s.garbage[node], key)
+			continue
+		}
+		info, active := s.nodes[node]
+		if !active || contains(s.fileChunks[key], node) {
+			continue
+		}
+		s.fileChunks[key] = append(s.fileChunks[key], node)
+		info.chunks++
+		log.Printf("Shard %s reconstruido en el nodo %s", key, node)
+	}
+}
+
+// requestRebuild envía la solicitud de reconstrucción al nodo elegido, con la orden firmada
+// que este verifica, el permiso para pedir los shards que quedan y el permiso para que los
+// nodos destino acepten guardar los reconstruidos.
+func (s *trackerServer) requestRebuild(node string, req *pb.RebuildShardsRequest) (map[string]string, error) {
+	var err error
+	if req.ReadToken, err = s.issueToken(req.FileId, node, security.ScopeRead); err != nil {
+		return nil, err
+	}
+	if req.Token, err = s.issueToken(req.FileId, node, security.ScopeWrite); err != nil {
+		return nil, err
+	}
+	if req.Order, err = s.issueToken(req.FileId, node, security.ScopeReplicate); err != nil {
+		return nil, err
+	}
+
+	conn, err := grpc.Dial(node, s.credentials.DialOption())
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+
+	client := pb.NewNodeServiceClient(conn)
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Minute)
+	defer cancel()
+
+	res, err := client.RebuildShards(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	return res.Stored, nil
+}
+
+// replicate pide a alguno de los nodos que tienen el chunk que lo copie a los nodos destino.
+func (s *trackerServer) replicate(task repairTask) {
+	for _, source := range task.sources {
+		stored, err := s.requestReplication(source, task.key.String(), task.targets)
+		if err != nil {
+			log.Printf("Error al re-replicar el chunk %s desde %s: %v", task.key, source, err)
+			continue
+		}
+
+		s.mu.Lock()
+		if _, exists := s.fileChunks[task.key]; exists {
+			for _, node := range stored {
+				info, active := s.nodes[node]
+				if !active || contains(s.fileChunks[task.key], node) {
+					continue
+				}
+				s.fileChunks[task.key] = append(s.fileChunks[task.key], node)
+				info.chunks++
+				log.Printf("Chunk %s re-replicado en el nodo %s", task.key, node)
+			}
+		} else if _, deleted := s.tombstones[task.key.fileID]; deleted {
+			// El archivo se eliminó mientras se copiaba: las copias nuevas también son basura
+			for _, node := range stored {
+				s.garbage[node] = append(s.garbage[node], task.key)
+			}
+		}
+		s.mu.Unlock()
+		return
+	}
+}
+
+// requestReplication envía la solicitud de copia al nodo origen, con la orden firmada que
+// este verifica y el permiso para que los nodos destino acepten guardar el chunk.
+func (s *trackerServer) requestReplication(source, chunkID string, targets []string) ([]string, error) {
+	key, valid := parseChunkKey(chunkID)
+	if !valid {
+		return nil, fmt.Errorf("chunk_id inválido: %s", chunkID)
+	}
+	token, err := s.issueToken(key.fileID, source, security.ScopeWrite)
+	if err != nil {
+		return nil, err
+	}
+	order, err := s.issueToken(key.fileID, source, security.ScopeReplicate)
+	if err != nil {
+		return nil, err
+	}
+
+	conn, err := grpc.Dial(source, s.credentials.DialOption())
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+
+	client := pb.NewNodeServiceClient(conn)
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	res, err := client.ReplicateChunk(ctx, &pb.ReplicateChunkRequest{ChunkId: chunkID, TargetNodes: targets, Token: token, Order: order})
+	if err != nil {
+		return nil, err
+	}
+	return res.StoredNodes, nil
+}
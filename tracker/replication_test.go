@@ -0,0 +1,134 @@
+package tracker
+
+import (
+	"fmt"
+	"slices"
+	"sort"
+	"testing"
+
+	pb "P2P_BitTorrent/pb"
+)
+
+func TestReplicationFor(t *testing.T) {
+	tests := []struct {
+		name       string
+		replicas   int32
+		durability string
+		want       int
+		wantErr    bool
+	}{
+		{name: "por defecto", want: DefaultReplicas},
+		{name: "explícito", replicas: 4, want: 4},
+		{name: "clase", durability: "high", want: 5},
+		{name: "explícito igual a la clase", replicas: 2, durability: "reduced", want: 2},
+		{name: "explícito distinto de la clase", replicas: 4, durability: "reduced", wantErr: true},
+		{name: "clase desconocida", durability: "eterna", wantErr: true},
+		{name: "negativo", replicas: -1, wantErr: true},
+		{name: "en el máximo", replicas: 6, want: 6},
+		{name: "sobre el máximo", replicas: 7, wantErr: true},
+		{name: "muy por encima del máximo", replicas: 1000, wantErr: true},
+	}
+	s := NewTrackerServer(Config{MaxReplicas: 6})
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := s.replicationFor(tt.replicas, tt.durability)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("replicationFor(%d, %q): error %v, se esperaba error: %v", tt.replicas, tt.durability, err, tt.wantErr)
+			}
+			if err == nil && got != tt.want {
+				t.Errorf("replicationFor(%d, %q) = %d, se esperaba %d", tt.replicas, tt.durability, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMaxReplicasCoversDefault(t *testing.T) {
+	s := NewTrackerServer(Config{Replicas: 12})
+	if got, err := s.replicationFor(0, ""); err != nil || got != 12 {
+		t.Errorf("replicationFor con el valor por defecto = %d, %v; se esperaba 12", got, err)
+	}
+}
+
+func TestPlanStripeRepairs(t *testing.T) {
+	// Archivo 2+1 con dos franjas: datos 1-2 y paridad 5 en la franja 0, datos 3-4 y paridad 6 en la franja 1.
+	layout := []chunkRef{
+		{stripe: 0, shard: 0}, {stripe: 0, shard: 1},
+		{stripe: 1, shard: 0}, {stripe: 1, shard: 1},
+		{stripe: 0, shard: 2}, {stripe: 1, shard: 2},
+	}
+	tests := []struct {
+		name          string
+		lost          []int32 // Índices de los chunks sin nodos.
+		noManifest    bool
+		wantLost      [][]int32 // Shards a reconstruir de cada franja reparada.
+		wantNeighbors [][]int32 // Vecinos de cada franja reparada.
+	}{
+		{name: "sin pérdidas"},
+		{name: "dato perdido", lost: []int32{3}, wantLost: [][]int32{{3}}, wantNeighbors: [][]int32{{2}}},
+		{name: "paridad perdida", lost: []int32{6}, wantLost: [][]int32{{6}}, wantNeighbors: [][]int32{nil}},
+		{name: "dato y paridad perdidos", lost: []int32{1, 5}, wantLost: nil},
+		{name: "una pérdida en cada franja", lost: []int32{2, 6}, wantLost: [][]int32{{2}, {6}}, wantNeighbors: [][]int32{{3}, nil}},
+		{name: "sin manifiesto", lost: []int32{3}, noManifest: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := NewTrackerServer(Config{})
+			file := &fileRecord{id: "archivo-1", name: "archivo", storageMode: StorageErasure, dataShards: 2, parityShards: 1}
+			if !tt.noManifest {
+				file.manifest = &pb.Manifest{FileId: file.id}
+			}
+			for i, chunk := range layout {
+				chunk.key = chunkKey{fileID: file.id, index: int32(i + 1)}
+				file.chunks = append(file.chunks, chunk)
+				if !slices.Contains(tt.lost, chunk.key.index) {
+					s.fileChunks[chunk.key] = []string{fmt.Sprintf("nodo-%d", i+1)}
+				}
+			}
+			for i := 1; i <= 10; i++ {
+				s.nodes[fmt.Sprintf("nodo-%d", i)] = &nodeInfo{failedChunks: make(map[chunkKey]bool)}
+			}
+
+			tasks := s.planStripeRepairs(file)
+			sort.Slice(tasks, func(i, j int) bool { return tasks[i].req.Shards[0].Stripe < tasks[j].req.Shards[0].Stripe })
+			if len(tasks) != len(tt.wantLost) {
+				t.Fatalf("se planearon %d reconstrucciones, se esperaban %d", len(tasks), len(tt.wantLost))
+			}
+			for i, task := range tasks {
+				stripe := task.req.Shards[0].Stripe
+				var lost []int32
+				for _, key := range task.keys {
+					lost = append(lost, key.index)
+				}
+				slices.Sort(lost)
+				if !slices.Equal(lost, tt.wantLost[i]) {
+					t.Errorf("franja %d: shards a reconstruir %v, se esperaban %v", stripe, lost, tt.wantLost[i])
+				}
+				var neighbors []int32
+				for _, info := range task.req.Neighbors {
+					neighbors = append(neighbors, info.Key.Index)
+				}
+				if !slices.Equal(neighbors, tt.wantNeighbors[i]) {
+					t.Errorf("franja %d: vecinos %v, se esperaban %v", stripe, neighbors, tt.wantNeighbors[i])
+				}
+
+				var holders []string
+				for _, info := range task.req.Shards {
+					if info.Stripe != stripe {
+						t.Errorf("franja %d: se incluyó el shard %d de la franja %d", stripe, info.Key.Index, info.Stripe)
+					}
+					holders = append(holders, info.Nodes...)
+				}
+				if !slices.Contains(holders, task.rebuilder) {
+					t.Errorf("franja %d: el nodo que reconstruye (%s) no tiene ningún shard de la franja", stripe, task.rebuilder)
+				}
+				seen := make(map[string]bool)
+				for chunkID, target := range task.req.Targets {
+					if slices.Contains(holders, target) || seen[target] {
+						t.Errorf("franja %d: el shard %s va al nodo %s, que ya tiene otro shard de la franja", stripe, chunkID, target)
+					}
+					seen[target] = true
+				}
+			}
+		})
+	}
+}
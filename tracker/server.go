@@ -2,26 +2,197 @@ package tracker
 
 import (
 	"context"
+	"crypto/ed25519"
 	"fmt"
 	"log"
 	"sync"
+	"time"
 
 	pb "P2P_BitTorrent/pb"
+	"P2P_BitTorrent/security"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
+// Tamaño que ocupa cada chunk en los nodos (1 chunk por MB).
+const chunkSizeBytes = 1 << 20
+
+// nodeInfo guarda la información que el tracker conoce de cada nodo activo.
+type nodeInfo struct {
+	chunks        int               // Cantidad de chunks asignados al nodo.
+	capacityBytes int64             // Capacidad reportada por el nodo (0 = sin límite).
+	freeBytes     int64             // Espacio libre estimado del nodo.
+	lastSeen      time.Time         // Último join o heartbeat recibido.
+	labels        map[string]string // Dominios de falla declarados por el nodo.
+	failures      int               // Desafíos de almacenamiento fallados seguidos.
+	unreliable    bool              // El nodo falló demasiados desafíos y no recibe chunks nuevos.
+	unreliableAt  time.Time         // Momento en que el nodo se marcó como no confiable.
+	failedChunks  map[chunkKey]bool // Chunks cuyo desafío falló el nodo; no se recuperan desde su inventario.
+	publicKey     ed25519.PublicKey // Clave con la que se registró el nodo; sus solicitudes deben estar firmadas con ella.
+}
+
+// hasRoomFor indica si el nodo puede recibir la cantidad de bytes indicada.
+func (n *nodeInfo) hasRoomFor(bytes int64) bool {
+	return n.capacityBytes <= 0 || n.freeBytes >= bytes
+}
+
+// canStore indica si se le pueden asignar al nodo chunks nuevos de la cantidad de bytes indicada.
+func (n *nodeInfo) canStore(bytes int64) bool {
+	return !n.unreliable && n.hasRoomFor(bytes)
+}
+
+// Cantidad de réplicas por chunk cuando no se configura otra, y máximo que se acepta por
+// archivo si no se configura otro.
+const (
+	DefaultReplicas    = 3
+	DefaultMaxReplicas = 10
+)
+
+// Config agrupa los parámetros configurables del tracker.
+type Config struct {
+	Placement     PlacementPolicy       // Política para elegir los nodos de cada réplica.
+	Replicas      int                   // Cantidad de réplicas por chunk.
+	MaxReplicas   int                   // Réplicas por chunk que puede pedir un archivo como máximo.
+	KeepVersions  int                   // Versiones que se conservan por archivo (0 = todas).
+	VersionMaxAge time.Duration         // Edad máxima de las versiones anteriores (0 = sin límite).
+	OrphanGrace   time.Duration         // Tiempo que se espera antes de corregir una diferencia de inventario.
+	Credentials   *security.Credentials // Credenciales de TLS para contactar a los nodos (nil = sin cifrar).
+	Identity      *security.Identity    // Clave con la que el tracker firma los permisos de acceso (nil = una temporal).
+	StorageQuota  int64                 // Bytes que pueden ocupar las versiones subidas por cada nodo (0 = sin límite).
+	DownloadQuota int64                 // Bytes que cada nodo puede descargar por período (0 = sin límite).
+	QuotaPeriod   time.Duration         // Período de la cuota de descarga.
+}
+
+// Modos de almacenamiento de un archivo.
+const (
+	StorageReplication = "replication" // Cada chunk se copia en varios nodos.
+	StorageErasure     = "ec"          // Los chunks se agrupan en franjas con shards de paridad.
+)
+
+// Tamaño de franja por defecto en modo erasure coding.
+const (
+	DefaultDataShards   = 4
+	DefaultParityShards = 2
+)
+
+// chunkRef identifica un chunk de un archivo y su posición.
+type chunkRef struct {
+	key    chunkKey
+	stripe int32 // Franja del shard (solo erasure coding).
+	shard  int32 // Posición del shard en su franja (solo erasure coding).
+}
+
+// fileRecord guarda la información de un archivo subido a la red.
+type fileRecord struct {
+	id           string       // Identificador único del archivo; de él se derivan los IDs de sus chunks.
+	name         string       // Nombre del archivo.
+	namespace    string       // Namespace del archivo (vacío = el namespace por defecto).
+	sizeMb       int32        // Tamaño del archivo en MB.
+	replicas     int          // Factor de replicación deseado para sus chunks.
+	durability   string       // Clase de durabilidad solicitada, si la hay.
+	storageMode  string       // Replicación o erasure coding.
+	dataShards   int32        // Shards de datos por franja (solo erasure coding).
+	parityShards int32        // Shards de paridad por franja (solo erasure coding).
+	chunks       []chunkRef   // Chunks del archivo, en orden.
+	version      int32        // Número de versión dentro de las subidas con el mismo nombre.
+	createdAt    time.Time    // Momento de la subida.
+	uploader     string       // Nodo que subió el archivo; es el único que puede publicar su manifiesto.
+	uploaderKey  string       // Clave del nodo que subió el archivo; a ella se le cuenta el espacio.
+	manifest     *pb.Manifest // Manifiesto firmado por el nodo que subió el archivo.
+	badDigests   bool         // Los resúmenes del manifiesto no coinciden con lo que guardan los nodos; no se desafían.
+}
+
+// key devuelve el nombre del archivo dentro de su namespace.
+func (f *fileRecord) key() fileKey {
+	return fileKey{namespace: f.namespace, name: f.name}
+}
+
 // Estructura para manejar la información del tracker.
 type trackerServer struct {
 	pb.UnimplementedTrackerServiceServer
-	mu         sync.Mutex          // Para proteger el acceso concurrente a las estructuras.
-	nodes      map[string]int      // Mapa de nodos activos con la cantidad de chunks que tienen.
-	fileChunks map[string][]string // Mapa de archivos con la lista de nodos que tienen sus chunks.
+	mu            sync.Mutex                        // Para proteger el acceso concurrente a las estructuras.
+	nodes         map[string]*nodeInfo              // Mapa de nodos activos con su carga y capacidad.
+	fileChunks    map[chunkKey][]string             // Mapa de chunks con la lista de nodos que los almacenan.
+	files         map[fileKey][]*fileRecord         // Versiones de cada archivo, de la más antigua a la más reciente.
+	lastVersions  map[fileKey]int32                 // Última versión asignada a cada nombre, aunque se hayan borrado todas.
+	repairMu      sync.Mutex                        // Evita que dos revisiones de re-replicación corran a la vez.
+	placement     PlacementPolicy                   // Política de ubicación de réplicas.
+	replicas      int                               // Réplicas por chunk.
+	maxReplicas   int                               // Réplicas por chunk que puede pedir un archivo como máximo.
+	keepVersions  int                               // Versiones que se conservan por archivo.
+	versionMaxAge time.Duration                     // Edad máxima de las versiones anteriores.
+	tombstones    map[string]*tombstone             // Archivos eliminados, por ID de archivo.
+	garbage       map[string][]chunkKey             // Chunks que cada nodo debe borrar.
+	gcMu          sync.Mutex                        // Evita que dos rondas del recolector corran a la vez.
+	orphanGrace   time.Duration                     // Espera antes de corregir una diferencia de inventario.
+	orphans       map[string]map[string]time.Time   // Chunks que cada nodo tiene sin que el tracker los conozca, y desde cuándo.
+	missing       map[string]map[chunkKey]time.Time // Chunks asignados que cada nodo no reporta, y desde cuándo.
+	pending       map[string]map[chunkKey]bool      // Chunks que cada nodo reportó sin tenerlos asignados, mientras se los desafía.
+	credentials   *security.Credentials             // Credenciales de TLS para contactar a los nodos.
+	acls          map[fileKey]*fileACL              // Permisos de cada nombre de archivo.
+	namespaces    map[string]*namespace             // Namespaces creados, por nombre.
+	identity      *security.Identity                // Clave con la que se firman los permisos de acceso.
+	storageQuota  int64                             // Bytes que pueden ocupar las versiones subidas por cada clave.
+	downloadQuota int64                             // Bytes que cada clave puede descargar por período.
+	quotaPeriod   time.Duration                     // Período de la cuota de descarga.
+	downloads     map[string]*downloadWindow        // Bytes descargados por cada clave en su período actual.
+	seenRequests  map[string]time.Time              // Firmas de solicitudes ya aceptadas, hasta que vencen.
+	lastSweep     time.Time                         // Última limpieza de las firmas vencidas.
 }
 
 // Crear una nueva instancia del servidor del tracker.
-func NewTrackerServer() *trackerServer {
+func NewTrackerServer(cfg Config) *trackerServer {
+	if cfg.Placement == nil {
+		cfg.Placement = leastLoadedPolicy{}
+	}
+	if cfg.Replicas <= 0 {
+		cfg.Replicas = DefaultReplicas
+	}
+	if cfg.MaxReplicas <= 0 {
+		cfg.MaxReplicas = DefaultMaxReplicas
+	}
+	if cfg.MaxReplicas < cfg.Replicas {
+		cfg.MaxReplicas = cfg.Replicas
+	}
+	if cfg.OrphanGrace <= 0 {
+		cfg.OrphanGrace = DefaultOrphanGrace
+	}
+	if cfg.QuotaPeriod <= 0 {
+		cfg.QuotaPeriod = DefaultQuotaPeriod
+	}
+	if cfg.Identity == nil {
+		identity, err := security.NewIdentity()
+		if err != nil {
+			panic(fmt.Sprintf("no se pudo generar la clave del tracker: %v", err))
+		}
+		cfg.Identity = identity
+	}
 	return &trackerServer{
-		nodes:      make(map[string]int),
-		fileChunks: make(map[string][]string),
+		nodes:         make(map[string]*nodeInfo),
+		fileChunks:    make(map[chunkKey][]string),
+		files:         make(map[fileKey][]*fileRecord),
+		placement:     cfg.Placement,
+		replicas:      cfg.Replicas,
+		maxReplicas:   cfg.MaxReplicas,
+		keepVersions:  cfg.KeepVersions,
+		versionMaxAge: cfg.VersionMaxAge,
+		lastVersions:  make(map[fileKey]int32),
+		tombstones:    make(map[string]*tombstone),
+		garbage:       make(map[string][]chunkKey),
+		orphanGrace:   cfg.OrphanGrace,
+		orphans:       make(map[string]map[string]time.Time),
+		missing:       make(map[string]map[chunkKey]time.Time),
+		pending:       make(map[string]map[chunkKey]bool),
+		credentials:   cfg.Credentials,
+		acls:          make(map[fileKey]*fileACL),
+		namespaces:    make(map[string]*namespace),
+		identity:      cfg.Identity,
+		storageQuota:  cfg.StorageQuota,
+		downloadQuota: cfg.DownloadQuota,
+		quotaPeriod:   cfg.QuotaPeriod,
+		downloads:     make(map[string]*downloadWindow),
+		seenRequests:  make(map[string]time.Time),
 	}
 }
 
@@ -32,22 +203,64 @@ func (s *trackerServer) JoinNetwork(ctx context.Context, req *pb.JoinRequest) (*
 
 	nodeID := req.NodeId
 	action := req.Action
-	fileName := req.FileName
+	key := fileKey{namespace: req.Namespace, name: req.FileName}
 
-	// Verificar si el nodo ya está registrado
-	if _, exists := s.nodes[nodeID]; !exists {
-		s.nodes[nodeID] = 0 // Registrar nodo con 0 chunks inicialmente
+	// Verificar la firma y, si el nodo ya está registrado, que venga de su misma clave
+	info, err := s.authenticate(ctx, nodeID, req)
+	if err != nil {
+		return nil, err
+	}
+	if info == nil {
+		// Registrar nodo con 0 chunks inicialmente, asociado a la clave que firmó la solicitud
+		info = &nodeInfo{publicKey: req.Auth.PublicKey, failedChunks: make(map[chunkKey]bool)}
+		s.nodes[nodeID] = info
 		log.Printf("Nodo %s conectado a la red para acción: %s", nodeID, action)
 	}
+	info.capacityBytes = req.CapacityBytes
+	info.freeBytes = req.FreeBytes
+	info.lastSeen = time.Now()
+	if len(req.Labels) > 0 {
+		info.labels = req.Labels
+	}
 
 	// Si la acción es 'put', gestionar la subida y fragmentación del archivo
 	if action == "put" {
-		return s.handlePut(fileName, req.FileSizeMb)
+		file, err := s.newFileRecord(req)
+		if err != nil {
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+		if err := s.checkPut(key, info.publicKey, req.FileSizeMb); err != nil {
+			return nil, err
+		}
+		file.uploaderKey = string(info.publicKey)
+		res, err := s.handlePut(file)
+		if err == nil {
+			s.claimFile(key, info.publicKey, req.Private)
+			res.AccessToken, err = s.issueToken(res.FileId, nodeID, security.ScopeWrite)
+		}
+		return res, err
 	}
 
-	// Si la acción es 'get', gestionar la solicitud de descarga
+	// Si la acción es 'get', gestionar la solicitud de descarga y entregar el permiso para
+	// pedir los chunks a los nodos
 	if action == "get" {
-		return s.handleGet(fileName)
+		if err := s.checkAccess(key, info.publicKey, false); err != nil {
+			return nil, err
+		}
+		res, err := s.handleGet(key, req.Version)
+		if err == nil && res.FileId != "" {
+			file, _ := s.lookupVersion(key, res.Version)
+			chunks, size, err := downloadChunks(file, req.Only)
+			if err != nil {
+				return nil, err
+			}
+			if err := s.chargeDownload(info.publicKey, size); err != nil {
+				return nil, err
+			}
+			res.AccessToken, err = s.issueToken(res.FileId, nodeID, security.ScopeRead, chunks...)
+			return res, err
+		}
+		return res, err
 	}
 
 	return &pb.JoinResponse{Message: "Acción desconocida."}, nil
@@ -59,9 +272,16 @@ func (s *trackerServer) LeaveNetwork(ctx context.Context, req *pb.LeaveRequest)
 	defer s.mu.Unlock()
 
 	nodeID := req.NodeId
+	info, err := s.authenticate(ctx, nodeID, req)
+	if err != nil {
+		return nil, err
+	}
+	if info == nil {
+		return &pb.LeaveResponse{Message: fmt.Sprintf("Nodo %s no registrado en la red.", nodeID)}, nil
+	}
 
 	// Eliminar el nodo de fileChunks
-	for chunkID, nodes := range s.fileChunks {
+	for key, nodes := range s.fileChunks {
 		// Filtrar los nodos que no sean el que está haciendo leave
 		newNodes := []string{}
 		for _, node := range nodes {
@@ -72,16 +292,41 @@ func (s *trackerServer) LeaveNetwork(ctx context.Context, req *pb.LeaveRequest)
 
 		// Si no quedan nodos almacenando el chunk, podemos eliminar el chunk (opcional)
 		if len(newNodes) > 0 {
-			s.fileChunks[chunkID] = newNodes
+			s.fileChunks[key] = newNodes
 		} else {
-			delete(s.fileChunks, chunkID)
+			delete(s.fileChunks, key)
 		}
 
 	}
 
 	// Eliminar el nodo de la lista de nodos activos
 	delete(s.nodes, nodeID)
+	delete(s.orphans, nodeID)
+	delete(s.missing, nodeID)
+	delete(s.pending, nodeID)
+
+	// Completar en segundo plano las réplicas que se perdieron con el nodo
+	go s.repairUnderReplicated()
 
 	log.Printf("Nodo %s salió de la red y fue eliminado de todos los chunks.", nodeID)
 	return &pb.LeaveResponse{Message: fmt.Sprintf("Nodo %s desconectado.", nodeID)}, nil
 }
+
+// Heartbeat actualiza la capacidad y el espacio libre reportados por un nodo registrado.
+func (s *trackerServer) Heartbeat(ctx context.Context, req *pb.HeartbeatRequest) (*pb.HeartbeatResponse, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	info, err := s.authenticate(ctx, req.NodeId, req)
+	if err != nil {
+		return nil, err
+	}
+	if info == nil {
+		return &pb.HeartbeatResponse{Message: fmt.Sprintf("Nodo %s no registrado en la red.", req.NodeId), TrackerKey: s.identity.PublicKey()}, nil
+	}
+
+	info.capacityBytes = req.CapacityBytes
+	info.freeBytes = req.FreeBytes
+	info.lastSeen = time.Now()
+	return &pb.HeartbeatResponse{Message: "Heartbeat recibido.", TrackerKey: s.identity.PublicKey()}, nil
+}
@@ -1,43 +1,114 @@
 package tracker
 
 import (
+	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"fmt"
+	"log"
+	"sort"
+	"strconv"
 	"strings"
-)
+	"time"
+
+	pb "P2P_BitTorrent/pb"
+	"P2P_BitTorrent/security"
 
-// selectNodesForChunk selecciona varios nodos basados en la disponibilidad (menos chunks).
-func (s *trackerServer) selectNodesForChunk(numReplicas int) []string {
-	var selectedNodes []string
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
 
-	// Crear una lista temporal de nodos que no han sido seleccionados
-	availableNodes := make(map[string]int)
-	for node, count := range s.nodes {
-		availableNodes[node] = count
+// selectNodesForChunk selecciona los nodos de las réplicas de un chunk según la política
+// de ubicación, descartando los que no tienen espacio libre para el chunk o no son
+// confiables, y repartiendo las réplicas entre dominios de falla distintos cuando es
+// posible. Los nodos de exclude (por ejemplo, los que ya tienen el chunk) no se consideran.
+func (s *trackerServer) selectNodesForChunk(chunkID string, numReplicas int, exclude []string) []string {
+	var candidates []Candidate
+	for node, info := range s.nodes {
+		if info.canStore(chunkSizeBytes) && !contains(exclude, node) {
+			candidates = append(candidates, Candidate{
+				NodeID:        node,
+				Chunks:        info.chunks,
+				CapacityBytes: info.capacityBytes,
+				FreeBytes:     info.freeBytes,
+				Labels:        info.labels,
+			})
+		}
 	}
 
-	// Seleccionar numReplicas nodos
-	for len(selectedNodes) < numReplicas {
+	// Ordenar para que las políticas no dependan del orden de iteración del mapa
+	sort.Slice(candidates, func(i, j int) bool { return candidates[i].NodeID < candidates[j].NodeID })
 
-		var selectedNode string
-		minChunks := int(^uint(0) >> 1) // Reiniciar el valor máximo en cada iteración
+	// La política ordena a todos los candidatos y luego se eligen respetando los dominios
+	byID := make(map[string]Candidate)
+	for _, c := range candidates {
+		byID[c.NodeID] = c
+	}
+	var ordered []Candidate
+	for _, nodeID := range s.placement.Select(chunkID, candidates, len(candidates)) {
+		ordered = append(ordered, byID[nodeID])
+	}
 
-		// Buscar el nodo con menos chunks que no haya sido seleccionado aún
-		for node, count := range availableNodes {
-			if count < minChunks && !contains(selectedNodes, node) { // Evitar seleccionar el mismo nodo
-				minChunks = count
-				selectedNode = node
-			}
+	var placed []Candidate
+	for _, node := range exclude {
+		if info, exists := s.nodes[node]; exists {
+			placed = append(placed, Candidate{NodeID: node, Labels: info.labels})
 		}
+	}
 
-		// Si no se seleccionó ningún nodo, salir del ciclo para evitar el bucle infinito
-		if selectedNode == "" {
-			break
-		}
+	return spreadAcrossDomains(ordered, numReplicas, placed)
+}
+
+// authenticate verifica la firma de una solicitud de un nodo y, si el nodo ya está
+// registrado, que esté firmada con la clave con la que se registró. Con TLS mutuo el node_id
+// además debe ser el del certificado del cliente. Cada firma se acepta una sola vez, para que
+// una solicitud capturada no pueda repetirse mientras su firma siga vigente. Devuelve la
+// información del nodo, o nil si todavía no está registrado. Debe llamarse con s.mu tomado.
+func (s *trackerServer) authenticate(ctx context.Context, nodeID string, req proto.Message) (*nodeInfo, error) {
+	auth, err := security.VerifyRequest(req)
+	if err != nil {
+		return nil, status.Errorf(codes.Unauthenticated, "solicitud del nodo %s rechazada: %v", nodeID, err)
+	}
+	if identity, verified := security.PeerIdentity(ctx); verified && identity != nodeID {
+		return nil, status.Errorf(codes.PermissionDenied, "el certificado pertenece a %s, no a %s", identity, nodeID)
+	}
+
+	info, exists := s.nodes[nodeID]
+	if exists && !info.publicKey.Equal(ed25519.PublicKey(auth.PublicKey)) {
+		log.Printf("Solicitud para el nodo %s firmada con una clave distinta a la registrada", nodeID)
+		return nil, status.Errorf(codes.PermissionDenied, "el nodo %s está registrado con otra clave", nodeID)
+	}
+	if !s.firstSeen(auth) {
+		log.Printf("Solicitud repetida del nodo %s rechazada", nodeID)
+		return nil, status.Errorf(codes.Unauthenticated, "solicitud del nodo %s rechazada: la firma ya se usó", nodeID)
+	}
+	if !exists {
+		return nil, nil
+	}
+	return info, nil
+}
 
-		selectedNodes = append(selectedNodes, selectedNode)
-		delete(availableNodes, selectedNode) // Quitar el nodo de la lista temporal
+// firstSeen registra la firma de una solicitud y devuelve false si ya se había aceptado.
+// Las firmas se recuerdan hasta que vencen, porque después VerifyRequest ya las rechaza.
+// Debe llamarse con s.mu tomado.
+func (s *trackerServer) firstSeen(auth *pb.NodeAuth) bool {
+	now := time.Now()
+	if now.Sub(s.lastSweep) > security.MaxClockSkew {
+		for signature, expires := range s.seenRequests {
+			if now.After(expires) {
+				delete(s.seenRequests, signature)
+			}
+		}
+		s.lastSweep = now
 	}
 
-	return selectedNodes
+	signature := string(auth.PublicKey) + string(auth.Signature)
+	if _, seen := s.seenRequests[signature]; seen {
+		return false
+	}
+	s.seenRequests[signature] = time.Unix(0, auth.Timestamp).Add(security.MaxClockSkew)
+	return true
 }
 
 // contains verifica si un nodo ya está en la lista de nodos seleccionados
@@ -50,25 +121,87 @@ func contains(nodes []string, node string) bool {
 	return false
 }
 
-// isValidChunk valida que chunkID pertenezca exactamente al archivo solicitado
-func isValidChunk(fileName, chunkID string) bool {
-	// Verificar si chunkID comienza con fileName seguido de un guion y un número
-	if !strings.HasPrefix(chunkID, fileName) {
-		return false
+// removeNode quita un nodo de la lista.
+func removeNode(nodes []string, node string) []string {
+	var remaining []string
+	for _, n := range nodes {
+		if n != node {
+			remaining = append(remaining, n)
+		}
 	}
-
-	// Asegurarse de que después del nombre del archivo haya un guion y un número
-	suffix := strings.TrimPrefix(chunkID, fileName)
-	return strings.HasPrefix(suffix, "-") && len(suffix) > 1 && isNumeric(suffix[1:])
+	return remaining
 }
 
-// isNumeric verifica si una cadena es numérica
-func isNumeric(s string) bool {
-	for _, c := range s {
-		if c < '0' || c > '9' {
-			return false
+// removeKey quita una clave de chunk de la lista.
+func removeKey(keys []chunkKey, key chunkKey) []chunkKey {
+	var remaining []chunkKey
+	for _, k := range keys {
+		if k != key {
+			remaining = append(remaining, k)
 		}
 	}
-	return true
+	return remaining
+}
+
+// fileKey identifica un nombre de archivo dentro de su namespace.
+type fileKey struct {
+	namespace string
+	name      string
+}
+
+// String devuelve el nombre del archivo precedido por su namespace, si no es el por defecto.
+func (k fileKey) String() string {
+	if k.namespace == "" {
+		return k.name
+	}
+	return k.namespace + ":" + k.name
+}
+
+// chunkKey identifica un chunk por el archivo al que pertenece y su número dentro de él.
+type chunkKey struct {
+	fileID string
+	index  int32
+}
 
+// String devuelve el chunk_id que se usa con los nodos, derivado de la clave.
+func (k chunkKey) String() string {
+	return fmt.Sprintf("%s-%d", k.fileID, k.index)
+}
+
+// parseChunkKey recupera la clave de un chunk a partir de su chunk_id.
+func parseChunkKey(chunkID string) (chunkKey, bool) {
+	i := strings.LastIndex(chunkID, "-")
+	if i <= 0 {
+		return chunkKey{}, false
+	}
+	index, err := strconv.Atoi(chunkID[i+1:])
+	if err != nil || index <= 0 {
+		return chunkKey{}, false
+	}
+	return chunkKey{fileID: chunkID[:i], index: int32(index)}, true
+}
+
+// proto convierte la clave a su representación en protobuf.
+func (k chunkKey) proto() *pb.ChunkKey {
+	return &pb.ChunkKey{FileId: k.fileID, Index: k.index}
+}
+
+// newFileID genera un identificador único (UUID v4) para un archivo.
+func newFileID() string {
+	var b [16]byte
+	if _, err := rand.Read(b[:]); err != nil {
+		panic(fmt.Sprintf("no se pudo generar el identificador del archivo: %v", err))
+	}
+	b[6] = (b[6] & 0x0f) | 0x40 // Versión 4
+	b[8] = (b[8] & 0x3f) | 0x80 // Variante RFC 4122
+	return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:16])
+}
+
+// copySet devuelve una copia de un conjunto de claves.
+func copySet(set map[string]bool) map[string]bool {
+	copied := make(map[string]bool, len(set))
+	for key := range set {
+		copied[key] = true
+	}
+	return copied
 }
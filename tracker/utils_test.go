@@ -0,0 +1,154 @@
+package tracker
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"testing"
+
+	pb "P2P_BitTorrent/pb"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+// tlsContext simula una conexión con TLS mutuo cuyo certificado de cliente tiene el CN indicado.
+func tlsContext(commonName string) context.Context {
+	cert := &x509.Certificate{Subject: pkix.Name{CommonName: commonName}}
+	state := tls.ConnectionState{VerifiedChains: [][]*x509.Certificate{{cert}}}
+	return peer.NewContext(context.Background(), &peer.Peer{AuthInfo: credentials.TLSInfo{State: state}})
+}
+
+func TestAuthenticateRejectsReplays(t *testing.T) {
+	s, nodes := newTestNetwork(t, Config{}, 2)
+	owner := nodes[0]
+
+	leave := &pb.LeaveRequest{NodeId: owner.id}
+	if err := owner.identity.Sign(leave); err != nil {
+		t.Fatalf("Sign: %v", err)
+	}
+	captured := proto.Clone(leave).(*pb.LeaveRequest)
+	if _, err := s.LeaveNetwork(context.Background(), leave); err != nil {
+		t.Fatalf("LeaveNetwork: %v", err)
+	}
+
+	// El nodo vuelve a la red y alguien repite la solicitud capturada
+	if _, err := owner.join(s, &pb.JoinRequest{Action: "noop"}); err != nil {
+		t.Fatalf("no se pudo volver a registrar el nodo: %v", err)
+	}
+	_, err := s.LeaveNetwork(context.Background(), captured)
+	if status.Code(err) != codes.Unauthenticated {
+		t.Errorf("la solicitud repetida devolvió %v, se esperaba %v", err, codes.Unauthenticated)
+	}
+	if _, registered := s.nodes[owner.id]; !registered {
+		t.Error("la solicitud repetida sacó al nodo de la red")
+	}
+
+	// Una solicitud nueva, firmada de nuevo, se sigue aceptando
+	if _, err := owner.join(s, &pb.JoinRequest{Action: "noop"}); err != nil {
+		t.Errorf("se rechazó una solicitud nueva del nodo: %v", err)
+	}
+}
+
+func TestAuthenticateBindsNodeToCertificate(t *testing.T) {
+	tests := []struct {
+		name       string
+		ctx        context.Context
+		nodeID     string
+		registered bool
+		wantCode   codes.Code
+	}{
+		{name: "sin TLS", ctx: context.Background(), nodeID: "10.0.0.9:50000", wantCode: codes.OK},
+		{name: "certificado del nodo", ctx: tlsContext("10.0.0.9:50000"), nodeID: "10.0.0.9:50000", wantCode: codes.OK},
+		{name: "certificado de otro nodo", ctx: tlsContext("10.0.0.8:50000"), nodeID: "10.0.0.9:50000", wantCode: codes.PermissionDenied},
+		{name: "nodo registrado con otro certificado", ctx: tlsContext("10.0.0.8:50000"), nodeID: "10.0.0.1:50000", registered: true, wantCode: codes.PermissionDenied},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, nodes := newTestNetwork(t, Config{}, 1)
+			node := nodes[0]
+			req := &pb.JoinRequest{NodeId: tt.nodeID, Action: "noop"}
+			if err := node.identity.Sign(req); err != nil {
+				t.Fatalf("Sign: %v", err)
+			}
+			_, err := s.JoinNetwork(tt.ctx, req)
+			if status.Code(err) != tt.wantCode {
+				t.Fatalf("JoinNetwork devolvió %v, se esperaba %v", err, tt.wantCode)
+			}
+			if _, registered := s.nodes[tt.nodeID]; registered != (tt.wantCode == codes.OK || tt.registered) {
+				t.Errorf("nodo %s registrado = %v", tt.nodeID, registered)
+			}
+		})
+	}
+}
+
+func TestParseChunkKey(t *testing.T) {
+	const uuid = "123e4567-e89b-12d3-a456-426614174000"
+	tests := []struct {
+		chunkID string
+		want    chunkKey
+		valid   bool
+	}{
+		{chunkID: uuid + "-1", want: chunkKey{fileID: uuid, index: 1}, valid: true},
+		{chunkID: uuid + "-12", want: chunkKey{fileID: uuid, index: 12}, valid: true},
+		{chunkID: "a-1-2", want: chunkKey{fileID: "a-1", index: 2}, valid: true},
+		{chunkID: "a"},
+		{chunkID: "-1"},
+		{chunkID: "a-0"},
+		{chunkID: "a-x"},
+		{chunkID: ""},
+	}
+	for _, tt := range tests {
+		got, valid := parseChunkKey(tt.chunkID)
+		if valid != tt.valid || got != tt.want {
+			t.Errorf("parseChunkKey(%q) = %v, %v; se esperaba %v, %v", tt.chunkID, got, valid, tt.want, tt.valid)
+		}
+		if valid && got.String() != tt.chunkID {
+			t.Errorf("chunkKey%v.String() = %q, se esperaba %q", got, got.String(), tt.chunkID)
+		}
+	}
+}
+
+func TestChunkIDsDoNotCollide(t *testing.T) {
+	s, nodes := newTestNetwork(t, Config{Replicas: 1}, 2)
+	uploader := nodes[0]
+
+	// "a-1" comparte el prefijo de los chunks de "a", y "a" se sube dos veces
+	seen := make(map[string]string)
+	fileIDs := make(map[string]bool)
+	for _, upload := range []struct{ name, namespace string }{{"a", ""}, {"a-1", ""}, {"a", ""}, {"a", "otro"}} {
+		if upload.namespace != "" && s.namespaces[upload.namespace] == nil {
+			req := &pb.NamespaceRequest{NodeId: uploader.id, Name: upload.namespace}
+			if err := uploader.identity.Sign(req); err != nil {
+				t.Fatalf("Sign: %v", err)
+			}
+			if _, err := s.UpdateNamespace(context.Background(), req); err != nil {
+				t.Fatalf("UpdateNamespace: %v", err)
+			}
+		}
+		res, err := uploader.join(s, &pb.JoinRequest{Action: "put", FileName: upload.name, Namespace: upload.namespace, FileSizeMb: 1})
+		if err != nil {
+			t.Fatalf("put de %s: %v", upload.name, err)
+		}
+		if fileIDs[res.FileId] {
+			t.Errorf("el put de %s reutilizó el file_id %s", upload.name, res.FileId)
+		}
+		fileIDs[res.FileId] = true
+		for chunkID, info := range res.ChunkMap {
+			if owner, exists := seen[chunkID]; exists {
+				t.Errorf("el chunk %s de %s ya era de %s", chunkID, upload.name, owner)
+			}
+			seen[chunkID] = upload.name
+			if len(info.Nodes) != 1 {
+				t.Errorf("el chunk %s tiene %d nodos, se esperaba 1", chunkID, len(info.Nodes))
+			}
+		}
+	}
+	if versions := s.files[fileKey{name: "a"}]; len(versions) != 2 {
+		t.Errorf("se guardaron %d versiones de \"a\", se esperaban 2", len(versions))
+	}
+}
@@ -0,0 +1,125 @@
+package tracker
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	pb "P2P_BitTorrent/pb"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Intervalo entre revisiones de las reglas de retención de versiones.
+const RetentionInterval = time.Minute
+
+// addVersion guarda un archivo recién subido como la versión más reciente de su nombre. Los
+// números de versión no se reutilizan: si se borraron todas las versiones, la numeración
+// sigue desde la última asignada.
+func (s *trackerServer) addVersion(file *fileRecord) {
+	versions := s.files[file.key()]
+	s.lastVersions[file.key()]++
+	file.version = s.lastVersions[file.key()]
+	file.createdAt = time.Now()
+	s.files[file.key()] = append(versions, file)
+
+	s.pruneVersions(file.key())
+}
+
+// allVersions devuelve todas las versiones de todos los archivos.
+func (s *trackerServer) allVersions() []*fileRecord {
+	var all []*fileRecord
+	for _, versions := range s.files {
+		all = append(all, versions...)
+	}
+	return all
+}
+
+// lookupVersion busca una versión de un archivo; la versión 0 es la más reciente.
+func (s *trackerServer) lookupVersion(fileName fileKey, version int32) (*fileRecord, bool) {
+	versions := s.files[fileName]
+	if len(versions) == 0 {
+		return nil, false
+	}
+	if version == 0 {
+		return versions[len(versions)-1], true
+	}
+	for _, file := range versions {
+		if file.version == version {
+			return file, true
+		}
+	}
+	return nil, false
+}
+
+// pruneVersions aplica las reglas de retención a las versiones de un archivo: se conservan
+// como máximo keepVersions versiones y se descartan las más antiguas que versionMaxAge.
+// La versión más reciente nunca se descarta.
+func (s *trackerServer) pruneVersions(fileName fileKey) {
+	versions := s.files[fileName]
+	now := time.Now()
+
+	var kept []*fileRecord
+	for i, file := range versions {
+		latest := i == len(versions)-1
+		tooMany := s.keepVersions > 0 && len(versions)-i > s.keepVersions
+		tooOld := s.versionMaxAge > 0 && now.Sub(file.createdAt) > s.versionMaxAge
+		if !latest && (tooMany || tooOld) {
+			s.removeFile(file)
+			log.Printf("Versión %d del archivo %s (id %s) descartada por la política de retención", file.version, fileName, file.id)
+			continue
+		}
+		kept = append(kept, file)
+	}
+	s.files[fileName] = kept
+}
+
+// StartRetentionLoop aplica periódicamente las reglas de retención a todos los archivos.
+func (s *trackerServer) StartRetentionLoop(interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for range ticker.C {
+		s.mu.Lock()
+		for fileName := range s.files {
+			s.pruneVersions(fileName)
+		}
+		s.mu.Unlock()
+	}
+}
+
+// ListVersions lista las versiones guardadas de un archivo a los nodos que pueden descargarlo.
+func (s *trackerServer) ListVersions(ctx context.Context, req *pb.FileRequest) (*pb.VersionsResponse, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	info, err := s.authenticate(ctx, req.NodeId, req)
+	if err != nil {
+		return nil, err
+	}
+	if info == nil {
+		return nil, status.Errorf(codes.PermissionDenied, "nodo %s no registrado en la red", req.NodeId)
+	}
+	key := fileKey{namespace: req.Namespace, name: req.FileName}
+	if err := s.checkAccess(key, info.publicKey, false); err != nil {
+		return nil, err
+	}
+	versions := s.files[key]
+	if len(versions) == 0 {
+		return nil, status.Error(codes.NotFound, fmt.Sprintf("archivo %s no encontrado en la red", key))
+	}
+
+	res := &pb.VersionsResponse{}
+	for _, file := range versions {
+		res.Versions = append(res.Versions, &pb.FileVersion{
+			Version:     file.version,
+			FileId:      file.id,
+			FileSizeMb:  file.sizeMb,
+			StorageMode: file.storageMode,
+			CreatedAt:   file.createdAt.Unix(),
+		})
+	}
+	return res, nil
+}